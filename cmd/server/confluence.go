@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ylchen07/atlassian-mcp/internal/config"
+	"github.com/ylchen07/atlassian-mcp/internal/export"
+	"github.com/ylchen07/atlassian-mcp/internal/publish"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOpts struct {
+		space       string
+		page        string
+		out         string
+		attachments bool
+		limit       int
+	}
+
+	publishOpts struct {
+		space  string
+		parent string
+		dryRun bool
+	}
+
+	confluenceCmd = &cobra.Command{
+		Use:   "confluence",
+		Short: "Confluence maintenance commands",
+	}
+
+	confluenceExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export a space or page tree to a directory of Markdown files",
+		Long: "Export a Confluence space or page subtree to Markdown with front-matter.\n" +
+			"Re-running into the same directory only rewrites pages whose version changed\n" +
+			"and removes files of pages that were deleted.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if (exportOpts.space == "") == (exportOpts.page == "") {
+				return fmt.Errorf("provide exactly one of --space or --page")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load configuration: %w", err)
+			}
+			service, baseURL, err := newConfluenceService(cfg)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			report, err := export.NewExporter(service).Export(cmd.Context(), export.Options{
+				SpaceKey:    exportOpts.space,
+				RootID:      exportOpts.page,
+				Dir:         exportOpts.out,
+				Attachments: exportOpts.attachments,
+				Limit:       exportOpts.limit,
+				BaseURL:     baseURL,
+				Progress: func(_, _ int, page export.Page) {
+					if page.Status == export.StatusWritten {
+						fmt.Fprintf(out, "wrote %s (v%d)\n", page.Path, page.Version)
+					}
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, removed := range report.Removed {
+				fmt.Fprintf(out, "removed %s\n", removed)
+			}
+			fmt.Fprintf(out, "%d pages: %d written, %d unchanged, %d removed\n", len(report.Pages), report.Written, report.Unchanged, len(report.Removed))
+			return nil
+		},
+	}
+
+	confluencePublishCmd = &cobra.Command{
+		Use:   "publish <dir>",
+		Short: "Publish a directory of Markdown files as a page tree",
+		Long: "Publish a directory of Markdown files to Confluence, one page per file, with\n" +
+			"subdirectories as child pages. Pages are matched through " + publish.MappingFile + ",\n" +
+			"an id in the front-matter, or their title; unchanged files are skipped.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("load configuration: %w", err)
+			}
+			service, _, err := newConfluenceService(cfg)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			verb := map[string]string{publish.StatusCreated: "created", publish.StatusUpdated: "updated"}
+			if publishOpts.dryRun {
+				verb = map[string]string{publish.StatusCreated: "would create", publish.StatusUpdated: "would update"}
+			}
+			report, err := publish.NewPublisher(service).Publish(cmd.Context(), publish.Options{
+				Dir:      args[0],
+				SpaceKey: publishOpts.space,
+				ParentID: publishOpts.parent,
+				DryRun:   publishOpts.dryRun,
+				Progress: func(_, _ int, page publish.Page) {
+					if v, ok := verb[page.Status]; ok {
+						fmt.Fprintf(out, "%s %s (%q)\n", v, page.Path, page.Title)
+					}
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, missing := range report.Missing {
+				fmt.Fprintf(out, "missing %s (page left in Confluence)\n", missing)
+			}
+			fmt.Fprintf(out, "%d pages: %d created, %d updated, %d unchanged\n", len(report.Pages), report.Created, report.Updated, report.Unchanged)
+			return nil
+		},
+	}
+)
+
+func init() {
+	flags := confluenceExportCmd.Flags()
+	flags.StringVar(&exportOpts.space, "space", "", "Space key to export")
+	flags.StringVar(&exportOpts.page, "page", "", "Page ID whose subtree to export")
+	flags.StringVarP(&exportOpts.out, "out", "o", ".", "Output directory")
+	flags.BoolVar(&exportOpts.attachments, "attachments", false, "Download page attachments")
+	flags.IntVar(&exportOpts.limit, "limit", 0, "Maximum pages to export (default 1000)")
+
+	flags = confluencePublishCmd.Flags()
+	flags.StringVar(&publishOpts.space, "space", "", "Space key to publish into")
+	flags.StringVar(&publishOpts.parent, "parent", "", "Parent page ID (default: the space homepage)")
+	flags.BoolVar(&publishOpts.dryRun, "dry-run", false, "Report changes without publishing")
+	_ = confluencePublishCmd.MarkFlagRequired("space")
+
+	confluenceCmd.AddCommand(confluenceExportCmd, confluencePublishCmd)
+	rootCmd.AddCommand(confluenceCmd)
+}
@@ -30,7 +30,7 @@ var (
 )
 
 func init() {
-	rootCmd.Flags().StringVarP(&cfgPath, "config", "c", "", "Path to configuration directory or file")
+	rootCmd.PersistentFlags().StringVarP(&cfgPath, "config", "c", "", "Path to configuration directory or file")
 }
 
 func main() {
@@ -52,11 +52,6 @@ func run(path string) error {
 		jiraSite = apiOverride
 	}
 
-	confluenceSite := ensureHTTPS(cfg.Atlassian.Confluence.Site)
-	if apiOverride := ensureHTTPS(cfg.Atlassian.Confluence.APIBase); apiOverride != "" {
-		confluenceSite = apiOverride
-	}
-
 	jiraClient, err := jira.NewClient(jiraSite, cfg.Atlassian.Jira.ServiceCredentials)
 	if err != nil {
 		logger.Error("failed to initialize Jira client", slog.Any("error", err))
@@ -64,24 +59,22 @@ func run(path string) error {
 	}
 	jiraSite = strings.TrimRight(jiraClient.BaseURL, "/")
 
-	confluenceClient, err := confluence.NewClient(confluenceSite, cfg.Atlassian.Confluence.ServiceCredentials)
+	confluenceService, confluenceUI, err := newConfluenceService(cfg)
 	if err != nil {
 		logger.Error("failed to initialize Confluence client", slog.Any("error", err))
-		return fmt.Errorf("initialize confluence client: %w", err)
+		return err
 	}
-	confluenceSite = strings.TrimRight(confluenceClient.BaseURL, "/")
 
 	stateCache := state.NewCache()
 
 	jiraService := jira.NewService(jiraClient)
-	confluenceService := confluence.NewService(confluenceClient)
 
 	srv := mcpserver.NewServer(mcpserver.Dependencies{
 		JiraService:       jiraService,
 		ConfluenceService: confluenceService,
 		Cache:             stateCache,
 		JiraBaseURL:       jiraSite,
-		ConfluenceBaseURL: buildConfluenceUIBase(confluenceSite),
+		ConfluenceBaseURL: confluenceUI,
 		Logger:            logger,
 	})
 
@@ -93,6 +86,22 @@ func run(path string) error {
 	return nil
 }
 
+// newConfluenceService builds the Confluence service from configuration and
+// returns it with the Confluence UI base URL.
+func newConfluenceService(cfg *config.Config) (*confluence.Service, string, error) {
+	site := ensureHTTPS(cfg.Atlassian.Confluence.Site)
+	if apiOverride := ensureHTTPS(cfg.Atlassian.Confluence.APIBase); apiOverride != "" {
+		site = apiOverride
+	}
+
+	client, err := confluence.NewClient(site, cfg.Atlassian.Confluence.ServiceCredentials)
+	if err != nil {
+		return nil, "", fmt.Errorf("initialize confluence client: %w", err)
+	}
+
+	return confluence.NewService(client), buildConfluenceUIBase(strings.TrimRight(client.BaseURL, "/")), nil
+}
+
 func ensureHTTPS(site string) string {
 	trimmed := strings.TrimSpace(site)
 	if trimmed == "" {
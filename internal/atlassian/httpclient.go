@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +26,39 @@ type HTTPClient struct {
 	HTTPClient *http.Client
 }
 
+// HTTPError is returned for responses with a 4xx or 5xx status code.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the server-provided back-off hint for 429/503 responses.
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// IsStatus reports whether err is an HTTPError with the given status code.
+func IsStatus(err error, code int) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == code
+}
+
+func newHTTPError(resp *http.Response) *HTTPError {
+	body, _ := io.ReadAll(resp.Body)
+	httpErr := &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+
+	if retry := strings.TrimSpace(resp.Header.Get("Retry-After")); retry != "" {
+		if seconds, err := strconv.Atoi(retry); err == nil {
+			httpErr.RetryAfter = time.Duration(seconds) * time.Second
+		} else if at, err := http.ParseTime(retry); err == nil {
+			httpErr.RetryAfter = time.Until(at)
+		}
+	}
+
+	return httpErr
+}
+
 // NewHTTPClient creates an HTTP client for Atlassian services with proper authentication.
 // The baseURL should include any context paths (e.g., https://domain.com/jira).
 func NewHTTPClient(baseURL string, creds config.ServiceCredentials) (*HTTPClient, error) {
@@ -77,14 +113,105 @@ func (c *HTTPClient) Do(ctx context.Context, method, path string, body interface
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	// Set authentication - prefer OAuth if available
+	c.authorize(req)
+
+	return c.HTTPClient.Do(req)
+}
+
+// authorize sets authentication on req - prefer OAuth if available.
+func (c *HTTPClient) authorize(req *http.Request) {
 	if c.OAuthToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.OAuthToken)
 	} else {
 		req.SetBasicAuth(c.Email, c.APIToken)
 	}
+}
 
-	return c.HTTPClient.Do(req)
+// PostMultipart uploads a single file as multipart/form-data under the "file"
+// field, together with any extra form fields. Atlassian requires the
+// X-Atlassian-Token header to bypass XSRF checks on upload endpoints.
+func (c *HTTPClient) PostMultipart(ctx context.Context, path, fileName string, data []byte, fields map[string]string, result interface{}) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return fmt.Errorf("create multipart file: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return fmt.Errorf("write multipart file: %w", err)
+	}
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return fmt.Errorf("write multipart field: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close multipart body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+path, &body)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Atlassian-Token", "no-check")
+	c.authorize(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return newHTTPError(resp)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetRaw downloads the response body of a GET request without decoding it.
+// A positive maxBytes fails the download once the body exceeds that size.
+// The returned string is the response Content-Type.
+func (c *HTTPClient) GetRaw(ctx context.Context, path string, maxBytes int64) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+path, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "*/*")
+	c.authorize(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, "", newHTTPError(resp)
+	}
+
+	reader := io.Reader(resp.Body)
+	if maxBytes > 0 {
+		reader = io.LimitReader(resp.Body, maxBytes+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("read response: %w", err)
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, "", fmt.Errorf("response exceeds %d bytes", maxBytes)
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
 }
 
 // Get is a helper for GET requests.
@@ -96,8 +223,7 @@ func (c *HTTPClient) Get(ctx context.Context, path string, result interface{}) e
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return newHTTPError(resp)
 	}
 
 	if result != nil {
@@ -118,8 +244,7 @@ func (c *HTTPClient) Post(ctx context.Context, path string, body interface{}, re
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return newHTTPError(resp)
 	}
 
 	if result != nil {
@@ -140,8 +265,7 @@ func (c *HTTPClient) Put(ctx context.Context, path string, body interface{}, res
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return newHTTPError(resp)
 	}
 
 	if result != nil && resp.StatusCode != http.StatusNoContent {
@@ -162,8 +286,7 @@ func (c *HTTPClient) Delete(ctx context.Context, path string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return newHTTPError(resp)
 	}
 
 	return nil
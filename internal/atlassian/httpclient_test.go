@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ylchen07/atlassian-mcp/internal/config"
 )
@@ -511,3 +513,127 @@ func TestHTTPClientDeleteError(t *testing.T) {
 		t.Fatalf("expected 403 in error message, got: %v", err)
 	}
 }
+
+func TestHTTPClientRateLimitError(t *testing.T) {
+	t.Parallel()
+
+	header := make(http.Header)
+	header.Set("Retry-After", "7")
+
+	mock := &mockRoundTripper{
+		response: &http.Response{
+			StatusCode: 429,
+			Body:       io.NopCloser(bytes.NewReader([]byte("Too Many Requests"))),
+			Header:     header,
+		},
+	}
+
+	client := &HTTPClient{
+		BaseURL:    "https://example.com",
+		Email:      "user",
+		APIToken:   "token",
+		HTTPClient: &http.Client{Transport: mock},
+	}
+
+	err := client.Get(context.Background(), "/api/test", nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("expected HTTPError, got %T: %v", err, err)
+	}
+
+	if httpErr.RetryAfter != 7*time.Second {
+		t.Fatalf("expected 7s retry-after, got %s", httpErr.RetryAfter)
+	}
+
+	if !IsStatus(err, http.StatusTooManyRequests) {
+		t.Fatalf("expected IsStatus to match 429")
+	}
+
+	if err.Error() != "HTTP 429: Too Many Requests" {
+		t.Fatalf("unexpected error message: %v", err)
+	}
+}
+
+func TestHTTPClientPostMultipart(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockRoundTripper{
+		response: &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"att1"}`))),
+			Header:     make(http.Header),
+		},
+	}
+
+	client := &HTTPClient{
+		BaseURL:    "https://example.com",
+		Email:      "user",
+		APIToken:   "token",
+		HTTPClient: &http.Client{Transport: mock},
+	}
+
+	var result map[string]string
+	err := client.PostMultipart(context.Background(), "/api/upload", "notes.txt", []byte("hello"), map[string]string{"comment": "first"}, &result)
+	if err != nil {
+		t.Fatalf("PostMultipart error: %v", err)
+	}
+	if result["id"] != "att1" {
+		t.Fatalf("unexpected result: %v", result)
+	}
+
+	req := mock.requests[0]
+	if req.Header.Get("X-Atlassian-Token") != "no-check" {
+		t.Fatalf("expected XSRF bypass header")
+	}
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatalf("parse multipart: %v", err)
+	}
+	if req.FormValue("comment") != "first" {
+		t.Fatalf("expected comment field, got %q", req.FormValue("comment"))
+	}
+
+	file, header, err := req.FormFile("file")
+	if err != nil {
+		t.Fatalf("expected file part: %v", err)
+	}
+	defer file.Close()
+	data, _ := io.ReadAll(file)
+	if header.Filename != "notes.txt" || string(data) != "hello" {
+		t.Fatalf("unexpected file part %s: %q", header.Filename, data)
+	}
+}
+
+func TestHTTPClientGetRawSizeLimit(t *testing.T) {
+	t.Parallel()
+
+	header := make(http.Header)
+	header.Set("Content-Type", "text/plain")
+
+	newClient := func() *HTTPClient {
+		return &HTTPClient{
+			BaseURL:  "https://example.com",
+			Email:    "user",
+			APIToken: "token",
+			HTTPClient: &http.Client{Transport: &mockRoundTripper{
+				response: &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewReader([]byte("0123456789"))),
+					Header:     header,
+				},
+			}},
+		}
+	}
+
+	data, contentType, err := newClient().GetRaw(context.Background(), "/download", 10)
+	if err != nil {
+		t.Fatalf("GetRaw error: %v", err)
+	}
+	if string(data) != "0123456789" || contentType != "text/plain" {
+		t.Fatalf("unexpected download %q (%s)", data, contentType)
+	}
+
+	if _, _, err := newClient().GetRaw(context.Background(), "/download", 5); err == nil {
+		t.Fatalf("expected size limit error")
+	}
+}
@@ -0,0 +1,137 @@
+package confluence
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListAttachments lists the attachments of a page. A non-empty fileName
+// restricts the result to that file.
+func (s *Service) ListAttachments(ctx context.Context, pageID, fileName string) ([]Attachment, error) {
+	if pageID == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+
+	var attachments []Attachment
+	for {
+		params := url.Values{}
+		params.Set("expand", "version,metadata")
+		params.Set("start", strconv.Itoa(len(attachments)))
+		params.Set("limit", strconv.Itoa(childPageSize))
+		if fileName != "" {
+			params.Set("filename", fileName)
+		}
+
+		var page struct {
+			Results []Attachment `json:"results"`
+			Links   struct {
+				Next string `json:"next"`
+			} `json:"_links"`
+		}
+		if err := s.client.Get(ctx, apiPath("content", pageID, "child", "attachment")+"?"+params.Encode(), &page); err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, page.Results...)
+		if len(page.Results) == 0 || page.Links.Next == "" {
+			return attachments, nil
+		}
+	}
+}
+
+// UploadAttachment attaches a file to a page. If the page already has an
+// attachment with the same name, a new version of it is uploaded instead.
+func (s *Service) UploadAttachment(ctx context.Context, pageID string, in AttachmentInput) (*Attachment, error) {
+	if pageID == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+	if in.FileName == "" {
+		return nil, fmt.Errorf("confluence: attachment filename required")
+	}
+	if len(in.Data) == 0 {
+		return nil, fmt.Errorf("confluence: attachment data required")
+	}
+
+	existing, err := s.ListAttachments(ctx, pageID, in.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{
+		"minorEdit": strconv.FormatBool(in.MinorEdit),
+	}
+	if in.Comment != "" {
+		fields["comment"] = in.Comment
+	}
+
+	path := apiPath("content", pageID, "child", "attachment")
+	for _, attachment := range existing {
+		if attachment.Title == in.FileName {
+			path = apiPath("content", pageID, "child", "attachment", attachment.ID, "data")
+			break
+		}
+	}
+
+	// Creating returns a result list; updating returns the attachment itself.
+	var response struct {
+		Attachment
+		Results []Attachment `json:"results"`
+	}
+	if err := s.client.PostMultipart(ctx, path, in.FileName, in.Data, fields, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Results) > 0 {
+		return &response.Results[0], nil
+	}
+	return &response.Attachment, nil
+}
+
+// DownloadAttachment fetches an attachment's contents. A positive maxBytes
+// fails the download for larger files.
+func (s *Service) DownloadAttachment(ctx context.Context, attachment Attachment, maxBytes int64) ([]byte, error) {
+	if attachment.Links.Download == "" {
+		return nil, fmt.Errorf("confluence: attachment %s has no download link", attachment.Title)
+	}
+	if maxBytes > 0 && attachment.Extensions.FileSize > maxBytes {
+		return nil, fmt.Errorf("confluence: attachment %s is %d bytes, over the %d byte limit", attachment.Title, attachment.Extensions.FileSize, maxBytes)
+	}
+
+	data, _, err := s.client.GetRaw(ctx, attachment.Links.Download, maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("confluence: download %s: %w", attachment.Title, err)
+	}
+
+	return data, nil
+}
+
+// ImageMarkup returns storage format markup that embeds an attached image.
+func ImageMarkup(fileName string, opts ImageOptions) string {
+	var b strings.Builder
+	b.WriteString("<ac:image")
+	if opts.Width > 0 {
+		fmt.Fprintf(&b, ` ac:width="%d"`, opts.Width)
+	}
+	if opts.Alt != "" {
+		fmt.Fprintf(&b, ` ac:alt="%s"`, escapeAttr(opts.Alt))
+	}
+	fmt.Fprintf(&b, `><ri:attachment ri:filename="%s"`, escapeAttr(fileName))
+	if opts.PageTitle != "" {
+		fmt.Fprintf(&b, `><ri:page ri:content-title="%s" /></ri:attachment>`, escapeAttr(opts.PageTitle))
+	} else {
+		b.WriteString(" />")
+	}
+	b.WriteString("</ac:image>")
+
+	return b.String()
+}
+
+func escapeAttr(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ylchen07/atlassian-mcp/internal/query"
+)
+
+// blogDateLayout is the date format used in CQL date comparisons.
+const blogDateLayout = "2006-01-02"
+
+// BlogPostQuery filters blog post listings. From is inclusive and To is
+// exclusive; zero times leave that end of the range open.
+type BlogPostQuery struct {
+	SpaceKey string
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
+
+// ListBlogPosts lists blog posts in a space by creation date, newest first.
+func (s *Service) ListBlogPosts(ctx context.Context, q BlogPostQuery) ([]Content, error) {
+	if q.SpaceKey == "" {
+		return nil, fmt.Errorf("confluence: space key required")
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return nil, fmt.Errorf("confluence: blog post range start must be before its end")
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 25
+	}
+
+	filter := query.CQLFilter{
+		Spaces:  []string{q.SpaceKey},
+		Types:   []string{TypeBlogPost},
+		OrderBy: "created DESC",
+	}
+	if !q.From.IsZero() {
+		filter.CreatedAfter = q.From.Format(blogDateLayout)
+	}
+	if !q.To.IsZero() {
+		filter.CreatedBefore = q.To.Format(blogDateLayout)
+	}
+	cql, err := query.BuildCQL(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("cql", cql)
+	params.Set("expand", "version,history,space")
+
+	return s.collectPages(ctx, apiPath("content/search"), params, limit)
+}
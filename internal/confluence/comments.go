@@ -0,0 +1,136 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListComments returns all comments on a page, including replies, in the
+// order Confluence reports them. Location filters to footer or inline
+// comments; empty returns both.
+func (s *Service) ListComments(ctx context.Context, pageID, location string) ([]Comment, error) {
+	if pageID == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+	switch location {
+	case "", CommentFooter, CommentInline:
+	default:
+		return nil, fmt.Errorf("confluence: invalid comment location %q (expected footer or inline)", location)
+	}
+
+	var comments []Comment
+	for {
+		params := url.Values{}
+		params.Set("expand", "body.storage,version,ancestors,history,extensions.inlineProperties,extensions.resolution")
+		params.Set("depth", "all")
+		params.Set("start", strconv.Itoa(len(comments)))
+		params.Set("limit", strconv.Itoa(childPageSize))
+		if location != "" {
+			params.Set("location", location)
+		}
+
+		var page struct {
+			Results []Comment `json:"results"`
+			Links   struct {
+				Next string `json:"next"`
+			} `json:"_links"`
+		}
+		if err := s.client.Get(ctx, apiPath("content", pageID, "child", "comment")+"?"+params.Encode(), &page); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, page.Results...)
+		if len(page.Results) == 0 || page.Links.Next == "" {
+			return comments, nil
+		}
+	}
+}
+
+// AddComment adds a footer comment to a page or blog post, or a reply when
+// parentID is set. contentType is the container's type and defaults to a
+// page. The body is in storage format.
+func (s *Service) AddComment(ctx context.Context, contentID, contentType, parentID, body string) (*Comment, error) {
+	if contentID == "" {
+		return nil, fmt.Errorf("confluence: content id required")
+	}
+	if body == "" {
+		return nil, fmt.Errorf("confluence: body required")
+	}
+	if contentType == "" {
+		contentType = TypePage
+	}
+
+	payload := map[string]interface{}{
+		"type": "comment",
+		"container": map[string]string{
+			"id":   contentID,
+			"type": contentType,
+		},
+		"body": map[string]interface{}{
+			"storage": map[string]string{
+				"value":          body,
+				"representation": "storage",
+			},
+		},
+	}
+	if parentID != "" {
+		payload["ancestors"] = []map[string]string{
+			{"id": parentID},
+		}
+	}
+
+	var created Comment
+	if err := s.client.Post(ctx, apiPath("content"), payload, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// ResolveComment marks an inline comment as resolved, or reopens it.
+func (s *Service) ResolveComment(ctx context.Context, commentID string, resolved bool) (*Comment, error) {
+	if commentID == "" {
+		return nil, fmt.Errorf("confluence: comment id required")
+	}
+
+	var current Comment
+	path := apiPath("content", commentID) + "?expand=body.storage,version,container,extensions.inlineProperties"
+	if err := s.client.Get(ctx, path, &current); err != nil {
+		return nil, err
+	}
+	if current.Extensions.Location != "" && current.Extensions.Location != CommentInline {
+		return nil, fmt.Errorf("confluence: comment %s is a %s comment; only inline comments can be resolved", commentID, current.Extensions.Location)
+	}
+
+	status := "open"
+	if resolved {
+		status = "resolved"
+	}
+
+	payload := map[string]interface{}{
+		"type":   "comment",
+		"status": "current",
+		"title":  current.Title,
+		"body": map[string]interface{}{
+			"storage": map[string]string{
+				"value":          current.Body.Storage.Value,
+				"representation": "storage",
+			},
+		},
+		"version": map[string]int{
+			"number": current.Version.Number + 1,
+		},
+		"extensions": map[string]string{
+			"resolution": status,
+		},
+	}
+
+	var updated Comment
+	if err := s.client.Put(ctx, apiPath("content", commentID), payload, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
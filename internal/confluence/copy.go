@@ -0,0 +1,179 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+)
+
+const (
+	defaultCopyLimit = 200
+	// copyAttachmentMaxBytes bounds the size of each attachment CopyPage
+	// transfers.
+	copyAttachmentMaxBytes = 100 << 20
+)
+
+// CopyPage duplicates a page, and optionally its descendants, under a new
+// parent. Parents are copied before their children so the tree shape is
+// preserved, and page links between copied pages are rewritten to the new
+// titles. On failure the pages copied so far are returned with the error.
+func (s *Service) CopyPage(ctx context.Context, id string, opts CopyOptions) ([]CopiedPage, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+
+	source, err := s.GetPage(ctx, id, []string{"space"})
+	if err != nil {
+		return nil, err
+	}
+	if source.Space == nil || source.Space.Key == "" {
+		return nil, fmt.Errorf("confluence: page %s has no space", id)
+	}
+
+	targetParent := opts.TargetParentID
+	targetSpace := opts.SpaceKey
+	switch {
+	case targetParent != "":
+		parent, err := s.GetPage(ctx, targetParent, []string{"space"})
+		if err != nil {
+			return nil, err
+		}
+		if parent.Space == nil || parent.Space.Key == "" {
+			return nil, fmt.Errorf("confluence: page %s has no space", targetParent)
+		}
+		targetSpace = parent.Space.Key
+	case targetSpace != "":
+		if targetParent, err = s.spaceHomepage(ctx, targetSpace); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("confluence: target parent id or space key required")
+	}
+
+	if strings.EqualFold(targetSpace, source.Space.Key) && opts.TitlePrefix == "" && opts.TitleSuffix == "" {
+		return nil, fmt.Errorf("confluence: a title prefix or suffix is required to copy within space %s", targetSpace)
+	}
+
+	nodes := []PageNode{{Content: *source, Depth: 0}}
+	if opts.IncludeDescendants {
+		limit := opts.Limit
+		if limit <= 0 {
+			limit = defaultCopyLimit
+		}
+		descendants, truncated, err := s.GetDescendants(ctx, id, DescendantOptions{MaxDepth: maxDescendantDepth, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		if truncated {
+			return nil, fmt.Errorf("confluence: page %s has more than %d descendants; raise the limit to copy the whole tree", id, limit)
+		}
+		nodes = append(nodes, descendants...)
+	}
+
+	titles := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		titles[node.Title] = opts.TitlePrefix + node.Title + opts.TitleSuffix
+	}
+
+	newIDs := map[string]string{}
+	copies := make([]CopiedPage, 0, len(nodes))
+	for _, node := range nodes {
+		parentID := targetParent
+		if node.Depth > 0 {
+			parentID = newIDs[node.ParentID]
+		}
+
+		copied, err := s.copyOne(ctx, node.ID, parentID, targetSpace, titles, opts)
+		if err != nil {
+			return copies, fmt.Errorf("confluence: copy page %s: %w", node.ID, err)
+		}
+		newIDs[node.ID] = copied.ID
+		copies = append(copies, *copied)
+
+		if opts.Progress != nil {
+			opts.Progress(len(copies), len(nodes), *copied)
+		}
+	}
+
+	return copies, nil
+}
+
+// copyOne copies a single page with its labels and attachments.
+func (s *Service) copyOne(ctx context.Context, id, parentID, spaceKey string, titles map[string]string, opts CopyOptions) (*CopiedPage, error) {
+	page, err := s.GetPage(ctx, id, []string{"body.storage"})
+	if err != nil {
+		return nil, err
+	}
+
+	in := PageInput{
+		SpaceKey: spaceKey,
+		Title:    titles[page.Title],
+		Body:     rewritePageLinks(page.Body.Storage.Value, titles),
+		ParentID: parentID,
+	}
+	if in.Body == "" {
+		in.Body = "<p></p>"
+	}
+
+	if opts.CopyLabels {
+		labels, err := s.GetLabels(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			in.Labels = append(in.Labels, label.Name)
+		}
+	}
+
+	created, err := s.CreatePage(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := &CopiedPage{SourceID: id, ID: created.ID, Title: created.Title, ParentID: parentID}
+	if !opts.CopyAttachments {
+		return copied, nil
+	}
+
+	attachments, err := s.ListAttachments(ctx, id, "")
+	if err != nil {
+		return copied, err
+	}
+	for _, attachment := range attachments {
+		data, err := s.DownloadAttachment(ctx, attachment, copyAttachmentMaxBytes)
+		if err != nil {
+			return copied, err
+		}
+		if _, err := s.UploadAttachment(ctx, created.ID, AttachmentInput{
+			FileName:  attachment.Title,
+			Data:      data,
+			Comment:   attachment.Metadata.Comment,
+			MinorEdit: true,
+		}); err != nil {
+			return copied, err
+		}
+		copied.Attachments++
+	}
+
+	return copied, nil
+}
+
+// rewritePageLinks points ri:page links at renamed pages to their new titles.
+func rewritePageLinks(storage string, titles map[string]string) string {
+	if !strings.Contains(storage, "ri:content-title=") {
+		return storage
+	}
+
+	pairs := make([]string, 0, 2*len(titles))
+	for old, renamed := range titles {
+		if old != renamed {
+			pairs = append(pairs, `ri:content-title="`+html.EscapeString(old)+`"`, `ri:content-title="`+html.EscapeString(renamed)+`"`)
+		}
+	}
+	if len(pairs) == 0 {
+		return storage
+	}
+
+	return strings.NewReplacer(pairs...).Replace(storage)
+}
@@ -0,0 +1,115 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+)
+
+// maxEditAttempts bounds how often EditPage re-reads a page after losing a
+// version race.
+const maxEditAttempts = 3
+
+// ConflictError reports that a page changed after the version the caller
+// based their edit on. Diff shows the intervening changes as text.
+type ConflictError struct {
+	ID       string
+	Expected int
+	Current  int
+	Diff     string
+}
+
+func (e *ConflictError) Error() string {
+	msg := fmt.Sprintf("confluence: page %s was modified concurrently (expected version %d, current version %d)", e.ID, e.Expected, e.Current)
+	if e.Diff != "" {
+		msg += "; changes since your version:\n" + e.Diff
+	}
+	return msg
+}
+
+// EditPage updates a page at its current version, bumping the version number
+// automatically. A 409 response from a concurrent write triggers a re-read
+// and retry unless ExpectedVersion is set, in which case a ConflictError is
+// returned.
+func (s *Service) EditPage(ctx context.Context, id string, edit PageEdit) (*Content, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+	if edit.Body != "" && edit.Apply != nil {
+		return nil, fmt.Errorf("confluence: body and apply are mutually exclusive")
+	}
+
+	for attempt := 1; ; attempt++ {
+		current, err := s.GetPage(ctx, id, []string{"body.storage", "version"})
+		if err != nil {
+			return nil, err
+		}
+
+		if edit.ExpectedVersion > 0 && current.Version.Number != edit.ExpectedVersion {
+			return nil, s.conflict(ctx, id, current, edit.ExpectedVersion)
+		}
+
+		in := PageInput{
+			Type:     current.Type,
+			SpaceKey: edit.SpaceKey,
+			Title:    edit.Title,
+			Body:     edit.Body,
+			ParentID: edit.ParentID,
+			Version:  current.Version.Number + 1,
+		}
+		if edit.Type != "" {
+			in.Type = edit.Type
+		}
+		if in.Title == "" {
+			in.Title = current.Title
+		}
+		if edit.Apply != nil {
+			if in.Body, err = edit.Apply(current.Body.Storage.Value); err != nil {
+				return nil, err
+			}
+		}
+		if in.Body == "" {
+			in.Body = current.Body.Storage.Value
+		}
+
+		updated, err := s.UpdatePage(ctx, id, in)
+		if err == nil {
+			return updated, nil
+		}
+		if !atlassian.IsStatus(err, http.StatusConflict) {
+			return nil, err
+		}
+
+		if edit.ExpectedVersion > 0 {
+			latest, latestErr := s.GetPage(ctx, id, []string{"body.storage", "version"})
+			if latestErr != nil {
+				return nil, err
+			}
+			return nil, s.conflict(ctx, id, latest, edit.ExpectedVersion)
+		}
+		if attempt >= maxEditAttempts {
+			return nil, fmt.Errorf("confluence: page %s kept changing, gave up after %d attempts: %w", id, attempt, err)
+		}
+	}
+}
+
+// conflict builds a ConflictError describing what changed between the
+// expected version and the current page. The diff is best effort.
+func (s *Service) conflict(ctx context.Context, id string, current *Content, expected int) *ConflictError {
+	conflict := &ConflictError{ID: id, Expected: expected, Current: current.Version.Number}
+
+	base, err := s.GetPageAtVersion(ctx, id, expected)
+	if err != nil {
+		return conflict
+	}
+
+	conflict.Diff = UnifiedDiff(
+		fmt.Sprintf("version %d", expected),
+		fmt.Sprintf("version %d", current.Version.Number),
+		StorageToText(base.Body.Storage.Value),
+		StorageToText(current.Body.Storage.Value),
+	)
+	return conflict
+}
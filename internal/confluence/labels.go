@@ -0,0 +1,209 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/query"
+)
+
+// popularLabelScanLimit bounds how many pages PopularLabels inspects.
+const popularLabelScanLimit = 1000
+
+// GetLabels lists the labels on a piece of content.
+func (s *Service) GetLabels(ctx context.Context, id string) ([]Label, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: content id required")
+	}
+
+	var labels []Label
+	for {
+		params := url.Values{}
+		params.Set("start", strconv.Itoa(len(labels)))
+		params.Set("limit", "200")
+
+		var page struct {
+			Results []Label `json:"results"`
+			Links   struct {
+				Next string `json:"next"`
+			} `json:"_links"`
+		}
+		if err := s.client.Get(ctx, apiPath("content", id, "label")+"?"+params.Encode(), &page); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, page.Results...)
+		if len(page.Results) == 0 || page.Links.Next == "" {
+			return labels, nil
+		}
+	}
+}
+
+// AddLabels adds global labels to a piece of content and returns the
+// resulting label set. Names are normalised with NormalizeLabel.
+func (s *Service) AddLabels(ctx context.Context, id string, names []string) ([]Label, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: content id required")
+	}
+
+	payload, err := labelPayload(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Results []Label `json:"results"`
+	}
+	if err := s.client.Post(ctx, apiPath("content", id, "label"), payload, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Results, nil
+}
+
+// RemoveLabel removes a label from a piece of content.
+func (s *Service) RemoveLabel(ctx context.Context, id, name string) error {
+	if id == "" {
+		return fmt.Errorf("confluence: content id required")
+	}
+
+	name, err := NormalizeLabel(name)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("name", name)
+
+	return s.client.Delete(ctx, apiPath("content", id, "label")+"?"+params.Encode())
+}
+
+// SearchByLabel finds pages carrying all of the given labels, optionally
+// restricted to a space.
+func (s *Service) SearchByLabel(ctx context.Context, labels []string, spaceKey string, limit int) ([]Content, error) {
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("confluence: at least one label required")
+	}
+
+	filter := query.CQLFilter{Types: []string{TypePage}, OrderBy: "lastmodified DESC"}
+	if spaceKey != "" {
+		filter.Spaces = []string{spaceKey}
+	}
+	for _, label := range labels {
+		name, err := NormalizeLabel(label)
+		if err != nil {
+			return nil, err
+		}
+		filter.Labels = append(filter.Labels, name)
+	}
+	cql, err := query.BuildCQL(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 25
+	}
+
+	// Only versions are expanded; listings have no use for page bodies.
+	params := url.Values{}
+	params.Set("cql", cql)
+	params.Set("expand", "version")
+
+	return s.collectPages(ctx, apiPath("content/search"), params, limit)
+}
+
+// PopularLabels tallies labels across the pages of a space and returns the
+// most used ones, most frequent first. Large spaces are sampled from the most
+// recently modified pages.
+func (s *Service) PopularLabels(ctx context.Context, spaceKey string, limit int) ([]LabelCount, error) {
+	if spaceKey == "" {
+		return nil, fmt.Errorf("confluence: space key required")
+	}
+	if limit <= 0 {
+		limit = 25
+	}
+
+	cql, err := query.BuildCQL(query.CQLFilter{
+		Spaces:  []string{spaceKey},
+		Types:   []string{TypePage},
+		OrderBy: "lastmodified DESC",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("cql", cql)
+	params.Set("expand", "metadata.labels")
+
+	pages, err := s.collectPages(ctx, apiPath("content/search"), params, popularLabelScanLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, page := range pages {
+		if page.Metadata == nil {
+			continue
+		}
+		for _, label := range page.Metadata.Labels.Results {
+			counts[label.Name]++
+		}
+	}
+
+	popular := make([]LabelCount, 0, len(counts))
+	for name, count := range counts {
+		popular = append(popular, LabelCount{Name: name, Count: count})
+	}
+	sort.Slice(popular, func(i, j int) bool {
+		if popular[i].Count != popular[j].Count {
+			return popular[i].Count > popular[j].Count
+		}
+		return popular[i].Name < popular[j].Name
+	})
+	if len(popular) > limit {
+		popular = popular[:limit]
+	}
+
+	return popular, nil
+}
+
+// NormalizeLabel lower-cases a label and replaces inner whitespace with
+// hyphens, matching how Confluence stores labels.
+func NormalizeLabel(name string) (string, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(name), "-"))
+	if normalized == "" {
+		return "", fmt.Errorf("confluence: label name required")
+	}
+	if strings.ContainsAny(normalized, ":;,.?&[]()#^*@!") {
+		return "", fmt.Errorf("confluence: label %q contains characters Confluence does not allow", name)
+	}
+	return normalized, nil
+}
+
+func labelPayload(names []string) ([]map[string]string, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("confluence: at least one label required")
+	}
+
+	payload := make([]map[string]string, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		normalized, err := NormalizeLabel(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		payload = append(payload, map[string]string{"prefix": "global", "name": normalized})
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,122 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DeletePage moves a page to the space trash. Trashed pages can be restored
+// with RestorePage until they are purged.
+func (s *Service) DeletePage(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("confluence: page id required")
+	}
+
+	return s.client.Delete(ctx, apiPath("content", id))
+}
+
+// PurgePage permanently removes a page that is already in the trash.
+func (s *Service) PurgePage(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("confluence: page id required")
+	}
+
+	return s.client.Delete(ctx, apiPath("content", id)+"?status=trashed")
+}
+
+// RestorePage restores a trashed page to its original location.
+func (s *Service) RestorePage(ctx context.Context, id string) (*Content, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+
+	var trashed Content
+	if err := s.client.Get(ctx, apiPath("content", id)+"?status=trashed&expand=version", &trashed); err != nil {
+		return nil, err
+	}
+	if trashed.Status != "" && trashed.Status != "trashed" {
+		return nil, fmt.Errorf("confluence: page %s is not in the trash (status %s)", id, trashed.Status)
+	}
+
+	payload := map[string]interface{}{
+		"id":     id,
+		"type":   "page",
+		"title":  trashed.Title,
+		"status": "current",
+		"version": map[string]int{
+			"number": trashed.Version.Number + 1,
+		},
+	}
+	if trashed.Type != "" {
+		payload["type"] = trashed.Type
+	}
+
+	var restored Content
+	if err := s.client.Put(ctx, apiPath("content", id), payload, &restored); err != nil {
+		return nil, err
+	}
+
+	return &restored, nil
+}
+
+// MovePage moves a page relative to another page, or under the homepage of
+// another space. The returned content includes the page's new ancestors and space.
+func (s *Service) MovePage(ctx context.Context, id string, in MoveInput) (*Content, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+
+	position := strings.ToLower(in.Position)
+	if position == "" {
+		position = MoveAppend
+	}
+	switch position {
+	case MoveBefore, MoveAfter, MoveAppend:
+	default:
+		return nil, fmt.Errorf("confluence: invalid move position %q (expected before, after or append)", in.Position)
+	}
+
+	if in.TargetID != "" && in.SpaceKey != "" {
+		return nil, fmt.Errorf("confluence: provide either a target page or a space key, not both")
+	}
+
+	target := in.TargetID
+	if target == "" {
+		if in.SpaceKey == "" {
+			return nil, fmt.Errorf("confluence: target page id or space key required")
+		}
+		if position != MoveAppend {
+			return nil, fmt.Errorf("confluence: position %s requires a target page", position)
+		}
+
+		homepage, err := s.spaceHomepage(ctx, in.SpaceKey)
+		if err != nil {
+			return nil, err
+		}
+		target = homepage
+	}
+	if target == id {
+		return nil, fmt.Errorf("confluence: cannot move a page relative to itself")
+	}
+
+	if err := s.client.Put(ctx, apiPath("content", id, "move", position, target), nil, nil); err != nil {
+		return nil, err
+	}
+
+	return s.GetPage(ctx, id, []string{"version", "ancestors", "space"})
+}
+
+// spaceHomepage returns the ID of a space's homepage.
+func (s *Service) spaceHomepage(ctx context.Context, spaceKey string) (string, error) {
+	var space Space
+	if err := s.client.Get(ctx, apiPath("space", url.PathEscape(spaceKey))+"?expand=homepage", &space); err != nil {
+		return "", err
+	}
+	if space.Homepage == nil || space.Homepage.ID == "" {
+		return "", fmt.Errorf("confluence: space %s has no homepage", spaceKey)
+	}
+
+	return space.Homepage.ID, nil
+}
@@ -0,0 +1,211 @@
+package confluence
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is wrapped by lookups that find no matching content.
+var ErrNotFound = errors.New("not found")
+
+// PageRef identifies a page either by ID or by space key and title, as
+// extracted from a Confluence link. Blog posts referenced by title also carry
+// their posting day (YYYY-MM-DD).
+type PageRef struct {
+	ID         string
+	SpaceKey   string
+	Title      string
+	PostingDay string
+}
+
+// FindPage looks up a page by its exact title within a space.
+func (s *Service) FindPage(ctx context.Context, spaceKey, title string, expand []string) (*Content, error) {
+	return s.findContent(ctx, TypePage, spaceKey, title, "", expand)
+}
+
+// FindBlogPost looks up a blog post by title and posting day (YYYY-MM-DD)
+// within a space.
+func (s *Service) FindBlogPost(ctx context.Context, spaceKey, title, postingDay string, expand []string) (*Content, error) {
+	if postingDay == "" {
+		return nil, fmt.Errorf("confluence: posting day required")
+	}
+	return s.findContent(ctx, TypeBlogPost, spaceKey, title, postingDay, expand)
+}
+
+func (s *Service) findContent(ctx context.Context, contentType, spaceKey, title, postingDay string, expand []string) (*Content, error) {
+	if spaceKey == "" {
+		return nil, fmt.Errorf("confluence: space key required")
+	}
+	if title == "" {
+		return nil, fmt.Errorf("confluence: title required")
+	}
+
+	params := url.Values{}
+	params.Set("type", contentType)
+	params.Set("spaceKey", spaceKey)
+	params.Set("title", title)
+	if postingDay != "" {
+		params.Set("postingDay", postingDay)
+	}
+	if len(expand) > 0 {
+		params.Set("expand", strings.Join(expand, ","))
+	}
+
+	var response struct {
+		Results []Content `json:"results"`
+	}
+	if err := s.client.Get(ctx, apiPath("content")+"?"+params.Encode(), &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Results) == 0 {
+		if contentType == TypeBlogPost {
+			return nil, fmt.Errorf("confluence: blog post %q from %s %w in space %s", title, postingDay, ErrNotFound, spaceKey)
+		}
+		return nil, fmt.Errorf("confluence: page %q %w in space %s", title, ErrNotFound, spaceKey)
+	}
+
+	return &response.Results[0], nil
+}
+
+// ResolvePage fetches the page or blog post referenced by ref.
+func (s *Service) ResolvePage(ctx context.Context, ref PageRef, expand []string) (*Content, error) {
+	if ref.ID != "" {
+		return s.GetPage(ctx, ref.ID, expand)
+	}
+	if ref.PostingDay != "" {
+		return s.FindBlogPost(ctx, ref.SpaceKey, ref.Title, ref.PostingDay, expand)
+	}
+	return s.FindPage(ctx, ref.SpaceKey, ref.Title, expand)
+}
+
+// ParsePageURL extracts a page reference from a Confluence link. Supported
+// forms, relative to baseURL, are Cloud links (/spaces/KEY/pages/ID/Title and
+// /spaces/KEY/blog/YYYY/MM/DD/ID/Title), Data Center links (/display/KEY/Title,
+// /display/KEY/YYYY/MM/DD/Title and /pages/viewpage.action?pageId=ID) and tiny
+// links (/x/AbCd). Absolute links must point at the same host as
+// baseURL.
+func ParsePageURL(baseURL, raw string) (PageRef, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return PageRef{}, fmt.Errorf("confluence: url required")
+	}
+
+	link, err := url.Parse(raw)
+	if err != nil {
+		return PageRef{}, fmt.Errorf("confluence: invalid url %q: %w", raw, err)
+	}
+
+	base, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return PageRef{}, fmt.Errorf("confluence: invalid base url %q: %w", baseURL, err)
+	}
+
+	if link.Host != "" && base.Host != "" && !strings.EqualFold(link.Host, base.Host) {
+		return PageRef{}, fmt.Errorf("confluence: url %q is not on %s", raw, base.Host)
+	}
+
+	path := link.EscapedPath()
+	switch {
+	case base.Path != "" && strings.HasPrefix(path, base.Path+"/"):
+		path = strings.TrimPrefix(path, base.Path)
+	case strings.HasPrefix(path, "/wiki/"):
+		path = strings.TrimPrefix(path, "/wiki")
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil {
+			return PageRef{}, fmt.Errorf("confluence: invalid url %q: %w", raw, err)
+		}
+		segments[i] = decoded
+	}
+
+	switch {
+	case len(segments) >= 4 && segments[0] == "spaces" && segments[2] == "pages":
+		// Cloud: /spaces/KEY/pages/ID/Title, optionally /pages/edit-v2/ID.
+		id := segments[3]
+		if !isNumeric(id) && len(segments) >= 5 {
+			id = segments[4]
+		}
+		if !isNumeric(id) {
+			break
+		}
+		return PageRef{ID: id, SpaceKey: segments[1]}, nil
+
+	case len(segments) >= 5 && segments[0] == "spaces" && segments[2] == "blog":
+		// Cloud blog posts: /spaces/KEY/blog/YYYY/MM/DD/ID/Title, or
+		// /spaces/KEY/blog/ID/Title on newer sites.
+		for _, id := range segments[3:] {
+			if isNumeric(id) && len(id) > 4 {
+				return PageRef{ID: id, SpaceKey: segments[1]}, nil
+			}
+		}
+
+	case len(segments) >= 6 && segments[0] == "display" && isNumeric(segments[2]) && isNumeric(segments[3]) && isNumeric(segments[4]):
+		return PageRef{
+			SpaceKey:   segments[1],
+			Title:      strings.ReplaceAll(segments[5], "+", " "),
+			PostingDay: segments[2] + "-" + segments[3] + "-" + segments[4],
+		}, nil
+
+	case len(segments) >= 3 && segments[0] == "display":
+		return PageRef{SpaceKey: segments[1], Title: strings.ReplaceAll(segments[2], "+", " ")}, nil
+
+	case len(segments) == 2 && segments[0] == "pages" && segments[1] == "viewpage.action":
+		if id := link.Query().Get("pageId"); isNumeric(id) {
+			return PageRef{ID: id}, nil
+		}
+
+	case len(segments) == 2 && segments[0] == "x":
+		id, err := DecodeTinyID(segments[1])
+		if err != nil {
+			return PageRef{}, err
+		}
+		return PageRef{ID: id}, nil
+	}
+
+	return PageRef{}, fmt.Errorf("confluence: unrecognised page url %q", raw)
+}
+
+// DecodeTinyID converts the identifier of a /x/ tiny link to a page ID. Tiny
+// identifiers are the page ID as little-endian bytes, base64 encoded with
+// trailing "A"s and padding removed and with '/' and '+' replaced by '-' and
+// '_'.
+func DecodeTinyID(tiny string) (string, error) {
+	encoded := strings.NewReplacer("-", "/", "_", "+").Replace(tiny)
+	for len(encoded)%4 != 0 {
+		encoded += "A"
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(data) == 0 {
+		return "", fmt.Errorf("confluence: invalid tiny link %q", tiny)
+	}
+
+	data = []byte(strings.TrimRight(string(data), "\x00"))
+	if len(data) > 8 {
+		return "", fmt.Errorf("confluence: invalid tiny link %q", tiny)
+	}
+
+	var id uint64
+	for i := len(data) - 1; i >= 0; i-- {
+		id = id<<8 | uint64(data[i])
+	}
+
+	return strconv.FormatUint(id, 10), nil
+}
+
+func isNumeric(value string) bool {
+	if value == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(value, 10, 64)
+	return err == nil
+}
@@ -0,0 +1,561 @@
+package confluence
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MarkdownLinks resolves link targets while converting storage format to
+// Markdown. A nil function, or one returning "", leaves the link as plain
+// text.
+type MarkdownLinks struct {
+	// Page resolves a link to a page by space key (empty for the current
+	// space) and title.
+	Page func(spaceKey, title string) string
+	// Attachment resolves an attachment of the current page by file name.
+	Attachment func(fileName string) string
+	// URL rewrites the href of a plain HTML link.
+	URL func(href string) string
+}
+
+// StorageToMarkdown converts Confluence storage format into GitHub flavoured
+// Markdown. Headings, lists, tables, code blocks, links, images, task lists
+// and the common panel macros are converted; other macros keep their body
+// content and drop their parameters.
+func StorageToMarkdown(storage string, links MarkdownLinks) string {
+	r := &markdownRenderer{links: links}
+	return strings.TrimSpace(r.blocks(parseStorage(storage).children, "\n\n")) + "\n"
+}
+
+// storageNode is an element or text node of a parsed storage document.
+type storageNode struct {
+	name     string
+	attrs    []xml.Attr
+	text     string
+	children []*storageNode
+}
+
+// parseStorage builds a node tree from storage format, tolerating unclosed
+// and mismatched tags.
+func parseStorage(storage string) *storageNode {
+	root := &storageNode{name: "root"}
+	stack := []*storageNode{root}
+	decoder := newStorageDecoder(storage)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF || err != nil {
+			break
+		}
+
+		top := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &storageNode{name: elementName(t.Name), attrs: t.Copy().Attr}
+			top.children = append(top.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			name := elementName(t.Name)
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		case xml.CharData:
+			top.children = append(top.children, &storageNode{text: string(t)})
+		}
+	}
+
+	// Unwrap the storageRoot element added by the decoder.
+	if len(root.children) == 1 && root.children[0].name == "root" {
+		return root.children[0]
+	}
+	return root
+}
+
+func (n *storageNode) attr(name string) string {
+	return attr(xml.StartElement{Attr: n.attrs}, name)
+}
+
+// child returns the first direct child element with the given name.
+func (n *storageNode) child(name string) *storageNode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// parameter returns the value of a macro parameter.
+func (n *storageNode) parameter(name string) string {
+	for _, c := range n.children {
+		if c.name == "ac:parameter" && strings.EqualFold(c.attr("ac:name"), name) {
+			return strings.TrimSpace(c.rawText())
+		}
+	}
+	return ""
+}
+
+// rawText concatenates all descendant text without altering whitespace.
+func (n *storageNode) rawText() string {
+	if n.name == "" {
+		return n.text
+	}
+	var b strings.Builder
+	for _, c := range n.children {
+		b.WriteString(c.rawText())
+	}
+	return b.String()
+}
+
+var markdownBlocks = map[string]bool{
+	"p": true, "div": true, "section": true, "blockquote": true, "pre": true,
+	"ul": true, "ol": true, "table": true, "hr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ac:structured-macro": true, "ac:macro": true, "ac:rich-text-body": true,
+	"ac:layout": true, "ac:layout-section": true, "ac:layout-cell": true,
+	"ac:task-list": true,
+}
+
+// inlineMacros render inside paragraphs rather than as blocks.
+var inlineMacros = map[string]bool{"status": true, "jira": true, "anchor": true}
+
+var panelMacros = map[string]string{
+	"info": "Info", "note": "Note", "tip": "Tip", "warning": "Warning", "panel": "",
+}
+
+type markdownRenderer struct {
+	links MarkdownLinks
+}
+
+// blocks renders a sequence of nodes as Markdown blocks joined by sep.
+// Inline runs between block elements become paragraphs.
+func (r *markdownRenderer) blocks(nodes []*storageNode, sep string) string {
+	var out []string
+	var run []*storageNode
+
+	flush := func() {
+		if text := r.inline(run); text != "" {
+			out = append(out, text)
+		}
+		run = nil
+	}
+
+	for _, node := range nodes {
+		if !r.isBlock(node) {
+			run = append(run, node)
+			continue
+		}
+		flush()
+		if block := r.block(node); strings.TrimSpace(block) != "" {
+			out = append(out, block)
+		}
+	}
+	flush()
+
+	return strings.Join(out, sep)
+}
+
+func (r *markdownRenderer) isBlock(node *storageNode) bool {
+	if node.name == "ac:structured-macro" {
+		return !inlineMacros[strings.ToLower(node.attr("ac:name"))]
+	}
+	return markdownBlocks[node.name]
+}
+
+func (r *markdownRenderer) block(node *storageNode) string {
+	switch node.name {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return strings.Repeat("#", int(node.name[1]-'0')) + " " + r.inline(node.children)
+	case "p":
+		return r.inline(node.children)
+	case "ul", "ol":
+		return r.list(node)
+	case "pre":
+		return fence(node.rawText(), "")
+	case "blockquote":
+		return quoteBlock(r.blocks(node.children, "\n\n"))
+	case "hr":
+		return "---"
+	case "table":
+		return r.table(node)
+	case "ac:task-list":
+		return r.tasks(node)
+	case "ac:structured-macro", "ac:macro":
+		return r.macro(node)
+	}
+
+	return r.blocks(node.children, "\n\n")
+}
+
+func (r *markdownRenderer) macro(node *storageNode) string {
+	name := strings.ToLower(node.attr("ac:name"))
+	body := node.child("ac:rich-text-body")
+
+	switch name {
+	case "code", "noformat":
+		text := ""
+		if plain := node.child("ac:plain-text-body"); plain != nil {
+			text = plain.rawText()
+		}
+		return fence(text, node.parameter("language"))
+	case "expand":
+		title := node.parameter("title")
+		if title == "" {
+			title = "Details"
+		}
+		content := ""
+		if body != nil {
+			content = r.blocks(body.children, "\n\n")
+		}
+		return strings.TrimSpace("**" + title + "**\n\n" + content)
+	}
+
+	if label, ok := panelMacros[name]; ok {
+		if title := node.parameter("title"); title != "" {
+			label = title
+		}
+		content := ""
+		if body != nil {
+			content = r.blocks(body.children, "\n\n")
+		}
+		if label != "" {
+			content = strings.TrimSpace("**" + label + ":** " + content)
+		}
+		return quoteBlock(content)
+	}
+
+	if body != nil {
+		return r.blocks(body.children, "\n\n")
+	}
+	if plain := node.child("ac:plain-text-body"); plain != nil {
+		return fence(plain.rawText(), "")
+	}
+	return ""
+}
+
+func (r *markdownRenderer) list(node *storageNode) string {
+	var items []string
+	n := 0
+	for _, item := range node.children {
+		if item.name != "li" {
+			continue
+		}
+		n++
+		marker := "- "
+		if node.name == "ol" {
+			marker = strconv.Itoa(n) + ". "
+		}
+		items = append(items, indentItem(marker, r.blocks(item.children, "\n")))
+	}
+	return strings.Join(items, "\n")
+}
+
+func (r *markdownRenderer) tasks(node *storageNode) string {
+	var items []string
+	for _, task := range node.children {
+		if task.name != "ac:task" {
+			continue
+		}
+		marker := "- [ ] "
+		if status := task.child("ac:task-status"); status != nil && strings.TrimSpace(status.rawText()) == "complete" {
+			marker = "- [x] "
+		}
+		body := ""
+		if b := task.child("ac:task-body"); b != nil {
+			body = r.blocks(b.children, "\n")
+		}
+		items = append(items, indentItem(marker, body))
+	}
+	return strings.Join(items, "\n")
+}
+
+func (r *markdownRenderer) table(node *storageNode) string {
+	var rows [][]string
+	var collect func(n *storageNode)
+	collect = func(n *storageNode) {
+		for _, c := range n.children {
+			switch c.name {
+			case "tr":
+				var cells []string
+				for _, cell := range c.children {
+					if cell.name == "td" || cell.name == "th" {
+						text := r.blocks(cell.children, "\n")
+						text = strings.ReplaceAll(text, "|", `\|`)
+						cells = append(cells, strings.ReplaceAll(text, "\n", "<br>"))
+					}
+				}
+				rows = append(rows, cells)
+			case "thead", "tbody", "tfoot":
+				collect(c)
+			}
+		}
+	}
+	collect(node)
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	var lines []string
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", width))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inline renders nodes as a single paragraph.
+func (r *markdownRenderer) inline(nodes []*storageNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		b.WriteString(r.inlineNode(node))
+	}
+
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.TrimSuffix(strings.TrimSpace(strings.Join(lines, "\n")), `\`)
+}
+
+func (r *markdownRenderer) inlineNode(node *storageNode) string {
+	switch node.name {
+	case "":
+		return escapeMarkdown(strings.ReplaceAll(node.text, "\n", " "))
+	case "strong", "b":
+		return wrapInline("**", r.inline(node.children), node)
+	case "em", "i":
+		return wrapInline("*", r.inline(node.children), node)
+	case "s", "del", "strike":
+		return wrapInline("~~", r.inline(node.children), node)
+	case "code", "tt":
+		return codeSpan(node.rawText())
+	case "br":
+		return "\\\n"
+	case "a":
+		text := r.inline(node.children)
+		href := node.attr("href")
+		if r.links.URL != nil {
+			href = r.links.URL(href)
+		}
+		if href == "" {
+			return text
+		}
+		if text == "" {
+			text = escapeMarkdown(href)
+		}
+		return "[" + text + "](" + linkDestination(href) + ")"
+	case "ac:link":
+		return r.link(node)
+	case "ac:image", "img":
+		return r.image(node)
+	case "ac:emoticon":
+		return node.attr("ac:emoji-fallback")
+	case "time":
+		return node.attr("datetime")
+	case "ac:structured-macro":
+		switch strings.ToLower(node.attr("ac:name")) {
+		case "status":
+			return "[" + strings.ToUpper(node.parameter("title")) + "]"
+		case "jira":
+			return node.parameter("key")
+		}
+		return ""
+	case "ac:parameter", "ac:placeholder", "style", "script":
+		return ""
+	}
+
+	if r.isBlock(node) {
+		return " " + r.blocks(node.children, " ") + " "
+	}
+	return r.inline(node.children)
+}
+
+func (r *markdownRenderer) link(node *storageNode) string {
+	var target, fallback string
+	for _, c := range node.children {
+		switch c.name {
+		case "ri:page", "ri:blog-post":
+			fallback = c.attr("ri:content-title")
+			if r.links.Page != nil {
+				target = r.links.Page(c.attr("ri:space-key"), fallback)
+			}
+		case "ri:attachment":
+			fallback = c.attr("ri:filename")
+			if r.links.Attachment != nil && c.child("ri:page") == nil {
+				target = r.links.Attachment(fallback)
+			}
+		case "ri:url":
+			target = c.attr("ri:value")
+			fallback = target
+		case "ri:user":
+			fallback = "@user"
+		case "ri:space":
+			fallback = c.attr("ri:space-key")
+		}
+	}
+
+	text := ""
+	if body := node.child("ac:link-body"); body != nil {
+		text = r.inline(body.children)
+	} else if body := node.child("ac:plain-text-link-body"); body != nil {
+		text = escapeMarkdown(strings.TrimSpace(body.rawText()))
+	}
+	if text == "" {
+		text = escapeMarkdown(fallback)
+	}
+	if anchor := node.attr("ac:anchor"); anchor != "" && target != "" {
+		target += "#" + anchor
+	}
+
+	if target == "" {
+		return text
+	}
+	return "[" + text + "](" + linkDestination(target) + ")"
+}
+
+func (r *markdownRenderer) image(node *storageNode) string {
+	alt := node.attr("ac:alt")
+	if alt == "" {
+		alt = node.attr("alt")
+	}
+	target := node.attr("src")
+
+	for _, c := range node.children {
+		switch c.name {
+		case "ri:attachment":
+			name := c.attr("ri:filename")
+			if alt == "" {
+				alt = name
+			}
+			if r.links.Attachment != nil && c.child("ri:page") == nil {
+				target = r.links.Attachment(name)
+			}
+		case "ri:url":
+			target = c.attr("ri:value")
+		}
+	}
+
+	if target == "" {
+		return escapeMarkdown("[image: " + alt + "]")
+	}
+	return "![" + escapeMarkdown(alt) + "](" + linkDestination(target) + ")"
+}
+
+// wrapInline surrounds text with an emphasis marker, keeping surrounding
+// whitespace outside the markers so the emphasis still parses.
+func wrapInline(marker, text string, node *storageNode) string {
+	if text == "" {
+		return ""
+	}
+	raw := node.rawText()
+	prefix, suffix := "", ""
+	if startsWithSpace(raw) {
+		prefix = " "
+	}
+	if endsWithSpace(raw) {
+		suffix = " "
+	}
+	return prefix + marker + text + marker + suffix
+}
+
+func codeSpan(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return ""
+	}
+	ticks := "`"
+	for strings.Contains(text, ticks) {
+		ticks += "`"
+	}
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		text = " " + text + " "
+	}
+	return ticks + text + ticks
+}
+
+func fence(text, language string) string {
+	text = strings.Trim(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	ticks := "```"
+	for strings.Contains(text, ticks) {
+		ticks += "`"
+	}
+	return ticks + language + "\n" + text + "\n" + ticks
+}
+
+func quoteBlock(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentItem prefixes the first line of a list item with its marker and
+// indents continuation lines to match.
+func indentItem(marker, body string) string {
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	pad := strings.Repeat(" ", len(marker))
+	for i := range lines {
+		if i == 0 {
+			lines[i] = marker + lines[i]
+		} else if lines[i] != "" {
+			lines[i] = pad + lines[i]
+		}
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), " ")
+}
+
+// linkDestination wraps destinations containing spaces or parentheses in
+// angle brackets.
+func linkDestination(target string) string {
+	if strings.ContainsAny(target, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(target) + ">"
+	}
+	return target
+}
+
+// escapeMarkdown escapes characters that would otherwise be read as Markdown
+// syntax. Underscores inside words are left alone.
+func escapeMarkdown(text string) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for i, c := range runes {
+		switch c {
+		case '\\', '*', '`', '[', ']', '<':
+			b.WriteByte('\\')
+		case '_':
+			inWord := i > 0 && i < len(runes)-1 && isWordRune(runes[i-1]) && isWordRune(runes[i+1])
+			if !inWord {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,649 @@
+package confluence
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StorageLinks resolves local references while converting Markdown to
+// storage format. A nil function, or one returning "", keeps the reference
+// as a plain URL.
+type StorageLinks struct {
+	// Page returns the title of the page a relative link points at.
+	Page func(href string) string
+	// Attachment returns the attachment file name for a local file
+	// referenced by an image or link.
+	Attachment func(src string) string
+}
+
+// MarkdownToStorage converts Markdown into Confluence storage format. It
+// understands CommonMark blocks and inlines plus GitHub tables, task lists,
+// strikethrough and alerts. Fenced code becomes the code macro, and
+// blockquotes starting with [!NOTE] or **Info:** style labels become panel
+// macros, the form StorageToMarkdown writes them in. Raw HTML is escaped.
+func MarkdownToStorage(markdown string, links StorageLinks) string {
+	c := &storageConverter{links: links}
+	markdown = strings.ReplaceAll(strings.ReplaceAll(markdown, "\r\n", "\n"), "\t", "    ")
+	return c.blocks(strings.Split(markdown, "\n"))
+}
+
+var (
+	headingLine   = regexp.MustCompile(`^(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	ruleLine      = regexp.MustCompile(`^([-*_])(\s*[-*_]){2,}\s*$`)
+	listItemLine  = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])( +|$)(.*)$`)
+	tableDelim    = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	taskPrefix    = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	alertPrefix   = regexp.MustCompile(`^\[!(NOTE|TIP|IMPORTANT|WARNING|CAUTION)\]\s*$`)
+	labelPrefix   = regexp.MustCompile(`^\*\*(Info|Note|Tip|Warning):\*\*\s*`)
+	fenceOpenLine = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+)
+
+// alertMacros maps GitHub alert types and exported panel labels to macros.
+var alertMacros = map[string]string{
+	"NOTE": "info", "TIP": "tip", "IMPORTANT": "note", "WARNING": "warning", "CAUTION": "warning",
+	"Info": "info", "Note": "note", "Tip": "tip", "Warning": "warning",
+}
+
+type storageConverter struct {
+	links StorageLinks
+}
+
+func (c *storageConverter) blocks(lines []string) string {
+	var out []string
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case fenceOpenLine.MatchString(line):
+			match := fenceOpenLine.FindStringSubmatch(line)
+			fence := match[1]
+			var code []string
+			i++
+			for ; i < len(lines); i++ {
+				closing := strings.TrimSpace(lines[i])
+				if strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+					i++
+					break
+				}
+				code = append(code, lines[i])
+			}
+			out = append(out, codeMacro(match[2], strings.Join(code, "\n")))
+
+		case headingLine.MatchString(line):
+			match := headingLine.FindStringSubmatch(line)
+			level := strconv.Itoa(len(match[1]))
+			out = append(out, "<h"+level+">"+c.inline(match[2])+"</h"+level+">")
+			i++
+
+		case ruleLine.MatchString(trimmed) && !strings.HasPrefix(line, "    "):
+			out = append(out, "<hr />")
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				inner := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(inner, " "))
+			}
+			out = append(out, c.quote(quoted))
+
+		case i+1 < len(lines) && strings.Contains(line, "|") && tableDelim.MatchString(strings.TrimSpace(lines[i+1])):
+			rows := [][]string{splitRow(line)}
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != ""; i++ {
+				rows = append(rows, splitRow(lines[i]))
+			}
+			out = append(out, c.table(rows))
+
+		case listItemLine.MatchString(line) && !strings.HasPrefix(line, "    "):
+			end := listEnd(lines, i)
+			out = append(out, c.list(lines[i:end]))
+			i = end
+
+		case strings.HasPrefix(line, "    "):
+			var code []string
+			for ; i < len(lines) && (strings.HasPrefix(lines[i], "    ") || strings.TrimSpace(lines[i]) == ""); i++ {
+				code = append(code, strings.TrimPrefix(lines[i], "    "))
+			}
+			out = append(out, codeMacro("", strings.TrimRight(strings.Join(code, "\n"), "\n")))
+
+		default:
+			var para []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(para) == 0 || !startsBlock(lines, i)); i++ {
+				para = append(para, lines[i])
+			}
+			out = append(out, "<p>"+c.paragraph(para)+"</p>")
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// startsBlock reports whether line i interrupts a paragraph.
+func startsBlock(lines []string, i int) bool {
+	line := lines[i]
+	trimmed := strings.TrimSpace(line)
+	return fenceOpenLine.MatchString(line) || headingLine.MatchString(line) ||
+		ruleLine.MatchString(trimmed) || strings.HasPrefix(trimmed, ">") ||
+		(listItemLine.MatchString(line) && !strings.HasPrefix(line, "    "))
+}
+
+// paragraph renders paragraph lines, honouring backslash and two-space hard
+// breaks.
+func (c *storageConverter) paragraph(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		hard := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`)
+		line = strings.TrimSpace(line)
+		if hard && i < len(lines)-1 {
+			line = strings.TrimSuffix(line, `\`)
+		}
+		b.WriteString(c.inline(line))
+		if i < len(lines)-1 {
+			if hard {
+				b.WriteString("<br />")
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+	}
+	return b.String()
+}
+
+func (c *storageConverter) quote(lines []string) string {
+	macro := ""
+	if len(lines) > 0 {
+		first := strings.TrimSpace(lines[0])
+		if match := alertPrefix.FindStringSubmatch(first); match != nil {
+			macro = alertMacros[match[1]]
+			lines = lines[1:]
+		} else if match := labelPrefix.FindStringSubmatch(first); match != nil {
+			macro = alertMacros[match[1]]
+			lines[0] = first[len(match[0]):]
+		}
+	}
+
+	body := c.blocks(lines)
+	if macro == "" {
+		return "<blockquote>" + body + "</blockquote>"
+	}
+	return `<ac:structured-macro ac:name="` + macro + `"><ac:rich-text-body>` + body + `</ac:rich-text-body></ac:structured-macro>`
+}
+
+func (c *storageConverter) table(rows [][]string) string {
+	var b strings.Builder
+	b.WriteString("<table><tbody>")
+	for r, row := range rows {
+		tag := "td"
+		if r == 0 {
+			tag = "th"
+		}
+		b.WriteString("<tr>")
+		for _, cell := range row {
+			b.WriteString("<" + tag + ">" + c.inline(cell) + "</" + tag + ">")
+		}
+		b.WriteString("</tr>")
+	}
+	b.WriteString("</tbody></table>")
+	return b.String()
+}
+
+// splitRow splits a table row on unescaped pipes.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// listEnd returns the index just past the list starting at line start. The
+// list ends at a block that is not indented below its items, or at an item
+// with a different kind of marker.
+func listEnd(lines []string, start int) int {
+	first := listItemLine.FindStringSubmatch(lines[start])
+	base := len(first[1])
+	kind := listKind(first[2])
+
+	sameList := func(line string) bool {
+		if indentOf(line) > base+1 {
+			return true
+		}
+		match := listItemLine.FindStringSubmatch(line)
+		return match != nil && listKind(match[2]) == kind
+	}
+
+	i := start + 1
+	for i < len(lines) {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			next := i + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next >= len(lines) || !sameList(lines[next]) {
+				return i
+			}
+			i = next
+			continue
+		}
+		if indentOf(line) <= base+1 && startsBlock(lines, i) && !sameList(line) {
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+// listKind distinguishes bullet lists by marker character and ordered lists
+// by delimiter, as CommonMark does when deciding where a list ends.
+func listKind(marker string) string {
+	if marker == "-" || marker == "*" || marker == "+" {
+		return marker
+	}
+	return marker[len(marker)-1:]
+}
+
+func (c *storageConverter) list(lines []string) string {
+	first := listItemLine.FindStringSubmatch(lines[0])
+	base := len(first[1])
+	ordered := first[2] != "-" && first[2] != "*" && first[2] != "+"
+
+	type item struct {
+		lines []string
+		loose bool
+	}
+	var items []*item
+	for _, line := range lines {
+		if match := listItemLine.FindStringSubmatch(line); match != nil && len(match[1]) <= base+1 {
+			items = append(items, &item{lines: []string{match[4]}})
+			continue
+		}
+		current := items[len(items)-1]
+		if strings.TrimSpace(line) == "" {
+			current.loose = true
+		}
+		current.lines = append(current.lines, dedent(line, base+len(first[2])+1))
+	}
+
+	tasks := !ordered
+	for _, it := range items {
+		if !taskPrefix.MatchString(it.lines[0]) {
+			tasks = false
+		}
+	}
+
+	var b strings.Builder
+	switch {
+	case tasks:
+		b.WriteString("<ac:task-list>")
+		for _, it := range items {
+			status := "incomplete"
+			if match := taskPrefix.FindStringSubmatch(it.lines[0]); match[1] != " " {
+				status = "complete"
+			}
+			it.lines[0] = taskPrefix.ReplaceAllString(it.lines[0], "")
+			b.WriteString("<ac:task><ac:task-status>" + status + "</ac:task-status><ac:task-body>")
+			b.WriteString(unwrapParagraph(c.blocks(it.lines), false))
+			b.WriteString("</ac:task-body></ac:task>")
+		}
+		b.WriteString("</ac:task-list>")
+		return b.String()
+	case ordered:
+		b.WriteString("<ol>")
+	default:
+		b.WriteString("<ul>")
+	}
+	for _, it := range items {
+		b.WriteString("<li>" + unwrapParagraph(c.blocks(it.lines), it.loose) + "</li>")
+	}
+	if ordered {
+		b.WriteString("</ol>")
+	} else {
+		b.WriteString("</ul>")
+	}
+	return b.String()
+}
+
+// unwrapParagraph drops the <p> around the leading paragraph of a tight list
+// item.
+func unwrapParagraph(body string, loose bool) string {
+	if loose || !strings.HasPrefix(body, "<p>") {
+		return body
+	}
+	end := strings.Index(body, "</p>")
+	if strings.Contains(body[3:end], "<p>") {
+		return body
+	}
+	return body[3:end] + strings.TrimPrefix(body[end+4:], "\n")
+}
+
+func dedent(line string, width int) string {
+	n := 0
+	for n < len(line) && n < width && line[n] == ' ' {
+		n++
+	}
+	return line[n:]
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func codeMacro(language, code string) string {
+	var b strings.Builder
+	b.WriteString(`<ac:structured-macro ac:name="code">`)
+	if language != "" {
+		b.WriteString(`<ac:parameter ac:name="language">` + escapeText(language) + `</ac:parameter>`)
+	}
+	b.WriteString("<ac:plain-text-body><![CDATA[")
+	b.WriteString(strings.ReplaceAll(code, "]]>", "]]]]><![CDATA[>"))
+	b.WriteString("]]></ac:plain-text-body></ac:structured-macro>")
+	return b.String()
+}
+
+// inline converts Markdown inline syntax on a single line.
+func (c *storageConverter) inline(text string) string {
+	var b strings.Builder
+	var plain strings.Builder
+	flush := func() {
+		b.WriteString(escapeText(plain.String()))
+		plain.Reset()
+	}
+	emit := func(markup string) {
+		flush()
+		b.WriteString(markup)
+	}
+
+	for i := 0; i < len(text); {
+		ch := text[i]
+		rest := text[i:]
+
+		switch {
+		case ch == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]):
+			plain.WriteByte(text[i+1])
+			i += 2
+			continue
+
+		case ch == '`':
+			run := len(rest) - len(strings.TrimLeft(rest, "`"))
+			ticks := rest[:run]
+			if end := closingTicks(rest[run:], ticks); end >= 0 {
+				code := rest[run : run+end]
+				if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				emit("<code>" + escapeText(code) + "</code>")
+				i += run + end + run
+				continue
+			}
+			plain.WriteString(ticks)
+			i += run
+			continue
+
+		case strings.HasPrefix(rest, "!["):
+			if label, dest, n := parseLink(rest[1:]); n > 0 {
+				emit(c.image(label, dest))
+				i += 1 + n
+				continue
+			}
+
+		case ch == '[':
+			if label, dest, n := parseLink(rest); n > 0 {
+				emit(c.link(label, dest))
+				i += n
+				continue
+			}
+
+		case ch == '<':
+			if end := strings.IndexByte(rest, '>'); end > 0 {
+				tag := rest[1:end]
+				if strings.HasPrefix(tag, "http://") || strings.HasPrefix(tag, "https://") || strings.HasPrefix(tag, "mailto:") {
+					emit(`<a href="` + escapeAttr(tag) + `">` + escapeText(tag) + `</a>`)
+					i += end + 1
+					continue
+				}
+				if t := strings.ReplaceAll(strings.ToLower(tag), " ", ""); t == "br" || t == "br/" {
+					emit("<br />")
+					i += end + 1
+					continue
+				}
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if end := closingDelimiter(rest[2:], rest[:2]); end > 0 {
+				emit("<strong>" + c.inline(rest[2:2+end]) + "</strong>")
+				i += 2 + end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if end := closingDelimiter(rest[2:], "~~"); end > 0 {
+				emit("<s>" + c.inline(rest[2:2+end]) + "</s>")
+				i += 2 + end + 2
+				continue
+			}
+
+		case ch == '*' || (ch == '_' && (i == 0 || !isWordByte(text[i-1]))):
+			if end := closingDelimiter(rest[1:], rest[:1]); end > 0 {
+				after := 1 + end + 1
+				if ch == '*' || after >= len(rest) || !isWordByte(rest[after]) {
+					emit("<em>" + c.inline(rest[1:1+end]) + "</em>")
+					i += after
+					continue
+				}
+			}
+		}
+
+		plain.WriteByte(ch)
+		i++
+	}
+	flush()
+
+	return b.String()
+}
+
+func (c *storageConverter) link(label, dest string) string {
+	body := c.inline(label)
+	target, anchor, _ := strings.Cut(dest, "#")
+
+	if target != "" && !isExternal(target) {
+		if c.links.Page != nil {
+			if title := c.links.Page(target); title != "" {
+				return pageLink(title, anchor, body)
+			}
+		}
+		if c.links.Attachment != nil {
+			if name := c.links.Attachment(target); name != "" {
+				return `<ac:link><ri:attachment ri:filename="` + escapeAttr(name) + `" />` + linkBody(body) + `</ac:link>`
+			}
+		}
+	}
+
+	if body == "" {
+		body = escapeText(dest)
+	}
+	return `<a href="` + escapeAttr(dest) + `">` + body + `</a>`
+}
+
+func (c *storageConverter) image(alt, src string) string {
+	if !isExternal(src) && c.links.Attachment != nil {
+		if name := c.links.Attachment(src); name != "" {
+			return ImageMarkup(name, ImageOptions{Alt: alt})
+		}
+	}
+
+	markup := "<ac:image"
+	if alt != "" {
+		markup += ` ac:alt="` + escapeAttr(alt) + `"`
+	}
+	return markup + `><ri:url ri:value="` + escapeAttr(src) + `" /></ac:image>`
+}
+
+func pageLink(title, anchor, body string) string {
+	markup := "<ac:link"
+	if anchor != "" {
+		markup += ` ac:anchor="` + escapeAttr(anchor) + `"`
+	}
+	return markup + `><ri:page ri:content-title="` + escapeAttr(title) + `" />` + linkBody(body) + `</ac:link>`
+}
+
+func linkBody(body string) string {
+	if body == "" {
+		return ""
+	}
+	return "<ac:link-body>" + body + "</ac:link-body>"
+}
+
+// parseLink parses "[label](dest)" at the start of s and returns the label,
+// the destination and the number of bytes consumed, or 0 if s does not start
+// with a link.
+func parseLink(s string) (string, string, int) {
+	depth := 0
+	closeLabel := -1
+	for i := 0; i < len(s) && closeLabel < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeLabel = i
+			}
+		}
+	}
+	if closeLabel < 0 || closeLabel+1 >= len(s) || s[closeLabel+1] != '(' {
+		return "", "", 0
+	}
+
+	rest := s[closeLabel+2:]
+	var dest string
+	var consumed int
+	if strings.HasPrefix(rest, "<") {
+		end := strings.IndexByte(rest, '>')
+		if end < 0 {
+			return "", "", 0
+		}
+		dest = rest[1:end]
+		closeParen := strings.IndexByte(rest[end:], ')')
+		if closeParen < 0 {
+			return "", "", 0
+		}
+		consumed = end + closeParen + 1
+	} else {
+		depth := 1
+		end := -1
+		for i := 0; i < len(rest) && end < 0; i++ {
+			switch rest[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			return "", "", 0
+		}
+		dest = rest[:end]
+		consumed = end + 1
+	}
+
+	// Drop an optional link title.
+	if fields := strings.Fields(dest); len(fields) > 0 && !strings.HasPrefix(rest, "<") {
+		dest = fields[0]
+	}
+	return s[1:closeLabel], strings.TrimSpace(dest), closeLabel + 2 + consumed
+}
+
+// closingTicks finds the backtick run closing a code span.
+func closingTicks(s, ticks string) int {
+	for i := 0; i < len(s); {
+		j := strings.Index(s[i:], ticks)
+		if j < 0 {
+			return -1
+		}
+		j += i
+		end := j + len(ticks)
+		if end >= len(s) || s[end] != '`' {
+			return j
+		}
+		for end < len(s) && s[end] == '`' {
+			end++
+		}
+		i = end
+	}
+	return -1
+}
+
+// closingDelimiter finds the emphasis delimiter closing a span. The span
+// must not start or end with whitespace.
+func closingDelimiter(s, delim string) int {
+	if s == "" || s[0] == ' ' {
+		return -1
+	}
+	for i := 1; i+len(delim) <= len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case s[i] == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				i += end + 1
+			}
+		case strings.HasPrefix(s[i:], delim) && s[i-1] != ' ':
+			if len(delim) == 1 && i+1 < len(s) && s[i+1] == delim[0] {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func isExternal(target string) bool {
+	if strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/") {
+		return true
+	}
+	scheme, _, ok := strings.Cut(target, ":")
+	return ok && !strings.ContainsAny(scheme, "/.")
+}
+
+func isASCIIPunct(ch byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", ch) >= 0
+}
+
+func isWordByte(ch byte) bool {
+	return ch >= 0x80 || ch == '_' || ('0' <= ch && ch <= '9') || ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z')
+}
+
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
@@ -6,8 +6,15 @@ import (
 	"strings"
 )
 
-// CreatePage creates a Confluence page.
+// CreatePage creates a Confluence page or blog post. With
+// CopyParentRestrictions set, the parent's restrictions are applied right after
+// the page is created; if that fails the error names the new page so the
+// caller can fix or remove it.
 func (s *Service) CreatePage(ctx context.Context, in PageInput) (*Content, error) {
+	contentType, err := contentType(in)
+	if err != nil {
+		return nil, err
+	}
 	if in.SpaceKey == "" {
 		return nil, fmt.Errorf("confluence: space key required")
 	}
@@ -19,7 +26,7 @@ func (s *Service) CreatePage(ctx context.Context, in PageInput) (*Content, error
 	}
 
 	payload := map[string]interface{}{
-		"type":  "page",
+		"type":  contentType,
 		"title": in.Title,
 		"space": map[string]string{
 			"key": in.SpaceKey,
@@ -38,16 +45,36 @@ func (s *Service) CreatePage(ctx context.Context, in PageInput) (*Content, error
 		}
 	}
 
+	if len(in.Labels) > 0 {
+		labels, err := labelPayload(in.Labels)
+		if err != nil {
+			return nil, err
+		}
+		payload["metadata"] = map[string]interface{}{
+			"labels": labels,
+		}
+	}
+
 	var created Content
 	if err := s.client.Post(ctx, apiPath("content"), payload, &created); err != nil {
 		return nil, err
 	}
 
+	if in.CopyParentRestrictions && in.ParentID != "" {
+		if err := s.CopyRestrictions(ctx, in.ParentID, created.ID); err != nil {
+			return nil, fmt.Errorf("confluence: page %s created but copying restrictions from %s failed: %w", created.ID, in.ParentID, err)
+		}
+	}
+
 	return &created, nil
 }
 
-// UpdatePage updates an existing Confluence page.
+// UpdatePage updates an existing Confluence page or blog post.
 func (s *Service) UpdatePage(ctx context.Context, id string, in PageInput) (*Content, error) {
+	contentType, err := contentType(in)
+	if err != nil {
+		return nil, err
+	}
 	if id == "" {
 		return nil, fmt.Errorf("confluence: page id required")
 	}
@@ -62,7 +89,7 @@ func (s *Service) UpdatePage(ctx context.Context, id string, in PageInput) (*Con
 	}
 
 	payload := map[string]interface{}{
-		"type":  "page",
+		"type":  contentType,
 		"title": in.Title,
 		"body": map[string]interface{}{
 			"storage": map[string]string{
@@ -114,3 +141,18 @@ func (s *Service) GetPage(ctx context.Context, id string, expand []string) (*Con
 
 	return &page, nil
 }
+
+// contentType validates and defaults the content type of a page input.
+func contentType(in PageInput) (string, error) {
+	switch in.Type {
+	case "", TypePage:
+		return TypePage, nil
+	case TypeBlogPost:
+		if in.ParentID != "" {
+			return "", fmt.Errorf("confluence: blog posts cannot have a parent page")
+		}
+		return TypeBlogPost, nil
+	default:
+		return "", fmt.Errorf("confluence: invalid content type %q (expected page or blogpost)", in.Type)
+	}
+}
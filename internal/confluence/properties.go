@@ -0,0 +1,173 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+)
+
+// PropertyConflictError reports that a content property changed after the
+// version the caller based their write on.
+type PropertyConflictError struct {
+	ID       string
+	Key      string
+	Expected int
+	Current  int
+}
+
+func (e *PropertyConflictError) Error() string {
+	return fmt.Sprintf("confluence: property %s on content %s was modified concurrently (expected version %d, current version %d)", e.Key, e.ID, e.Expected, e.Current)
+}
+
+// ListProperties lists the content properties of a piece of content.
+func (s *Service) ListProperties(ctx context.Context, id string) ([]ContentProperty, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: content id required")
+	}
+
+	var properties []ContentProperty
+	for {
+		params := url.Values{}
+		params.Set("start", strconv.Itoa(len(properties)))
+		params.Set("limit", strconv.Itoa(childPageSize))
+		params.Set("expand", "version")
+
+		var page struct {
+			Results []ContentProperty `json:"results"`
+			Links   struct {
+				Next string `json:"next"`
+			} `json:"_links"`
+		}
+		if err := s.client.Get(ctx, apiPath("content", id, "property")+"?"+params.Encode(), &page); err != nil {
+			return nil, err
+		}
+
+		properties = append(properties, page.Results...)
+		if len(page.Results) == 0 || page.Links.Next == "" {
+			return properties, nil
+		}
+	}
+}
+
+// GetProperty retrieves one content property.
+func (s *Service) GetProperty(ctx context.Context, id, key string) (*ContentProperty, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: content id required")
+	}
+	if key == "" {
+		return nil, fmt.Errorf("confluence: property key required")
+	}
+
+	var property ContentProperty
+	if err := s.client.Get(ctx, apiPath("content", id, "property", url.PathEscape(key))+"?expand=version", &property); err != nil {
+		return nil, err
+	}
+
+	return &property, nil
+}
+
+// SetProperty creates or updates a content property, bumping its version
+// automatically. A positive expectedVersion turns concurrent writes into a
+// PropertyConflictError; otherwise lost version races are retried. Use
+// expectedVersion 0 to write regardless of the current version.
+func (s *Service) SetProperty(ctx context.Context, id, key string, value interface{}, expectedVersion int) (*ContentProperty, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: content id required")
+	}
+	if key == "" {
+		return nil, fmt.Errorf("confluence: property key required")
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("confluence: encode property %s: %w", key, err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		current, err := s.GetProperty(ctx, id, key)
+		if err != nil && !atlassian.IsStatus(err, http.StatusNotFound) {
+			return nil, err
+		}
+
+		currentVersion := 0
+		if current != nil {
+			currentVersion = current.Version.Number
+		}
+		if expectedVersion > 0 && currentVersion != expectedVersion {
+			return nil, &PropertyConflictError{ID: id, Key: key, Expected: expectedVersion, Current: currentVersion}
+		}
+
+		payload := map[string]interface{}{
+			"key":   key,
+			"value": json.RawMessage(encoded),
+		}
+
+		var written ContentProperty
+		if current == nil {
+			err = s.client.Post(ctx, apiPath("content", id, "property"), payload, &written)
+		} else {
+			payload["version"] = map[string]interface{}{
+				"number":    currentVersion + 1,
+				"minorEdit": true,
+			}
+			err = s.client.Put(ctx, apiPath("content", id, "property", url.PathEscape(key)), payload, &written)
+		}
+		if err == nil {
+			return &written, nil
+		}
+		if !atlassian.IsStatus(err, http.StatusConflict) {
+			return nil, err
+		}
+		if expectedVersion > 0 || attempt >= maxEditAttempts {
+			conflict := &PropertyConflictError{ID: id, Key: key, Expected: currentVersion}
+			if latest, err := s.GetProperty(ctx, id, key); err == nil {
+				conflict.Current = latest.Version.Number
+			}
+			return nil, conflict
+		}
+	}
+}
+
+// DeleteProperty removes a content property.
+func (s *Service) DeleteProperty(ctx context.Context, id, key string) error {
+	if id == "" {
+		return fmt.Errorf("confluence: content id required")
+	}
+	if key == "" {
+		return fmt.Errorf("confluence: property key required")
+	}
+
+	return s.client.Delete(ctx, apiPath("content", id, "property", url.PathEscape(key)))
+}
+
+// StampPage records provenance metadata such as generated-by or
+// source-commit on a page, one string property per key, written in key
+// order. Existing values are overwritten.
+func (s *Service) StampPage(ctx context.Context, id string, stamps map[string]string) ([]ContentProperty, error) {
+	if len(stamps) == 0 {
+		return nil, fmt.Errorf("confluence: at least one stamp required")
+	}
+
+	keys := make([]string, 0, len(stamps))
+	for key := range stamps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	written := make([]ContentProperty, 0, len(keys))
+	for _, key := range keys {
+		property, err := s.SetProperty(ctx, id, key, stamps[key], 0)
+		if err != nil {
+			return written, err
+		}
+		written = append(written, *property)
+	}
+
+	return written, nil
+}
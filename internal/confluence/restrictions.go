@@ -0,0 +1,393 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+)
+
+// restrictionResponse is the wire format of content restrictions.
+type restrictionResponse struct {
+	Results []struct {
+		Operation    string `json:"operation"`
+		Restrictions struct {
+			User struct {
+				Results []User `json:"results"`
+			} `json:"user"`
+			Group struct {
+				Results []struct {
+					Name string `json:"name"`
+				} `json:"results"`
+			} `json:"group"`
+		} `json:"restrictions"`
+	} `json:"results"`
+}
+
+// restrictions converts the response to one Restriction per operation, view
+// first. Operations missing from the response are returned unrestricted.
+func (r restrictionResponse) restrictions() []Restriction {
+	out := []Restriction{{Operation: RestrictionView}, {Operation: RestrictionEdit}}
+	for _, result := range r.Results {
+		for i := range out {
+			if out[i].Operation != result.Operation {
+				continue
+			}
+			out[i].Users = append(out[i].Users, result.Restrictions.User.Results...)
+			for _, group := range result.Restrictions.Group.Results {
+				out[i].Groups = append(out[i].Groups, group.Name)
+			}
+		}
+	}
+	return out
+}
+
+// GetRestrictions returns the view and edit restrictions set directly on a
+// page. View restrictions on ancestors also apply but are not included.
+func (s *Service) GetRestrictions(ctx context.Context, id string) ([]Restriction, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+
+	params := url.Values{}
+	params.Set("expand", "restrictions.user,restrictions.group")
+
+	var response restrictionResponse
+	if err := s.client.Get(ctx, apiPath("content", id, "restriction")+"?"+params.Encode(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.restrictions(), nil
+}
+
+// UpdateRestrictions adds and removes users and groups on one operation of a
+// page, leaving the other operation as it is, and returns the resulting
+// restrictions.
+func (s *Service) UpdateRestrictions(ctx context.Context, id string, change RestrictionChange) ([]Restriction, error) {
+	operation, err := NormalizeOperation(change.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetRestrictions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range current {
+		if current[i].Operation != operation {
+			continue
+		}
+		r := &current[i]
+
+		users := r.Users[:0]
+		for _, user := range r.Users {
+			if !containsFold(change.RemoveUsers, user.AccountID) && !containsFold(change.RemoveUsers, user.Username) {
+				users = append(users, user)
+			}
+		}
+		for _, user := range change.AddUsers {
+			if user = strings.TrimSpace(user); user != "" && !hasUser(users, user) {
+				users = append(users, newRestrictionUser(user))
+			}
+		}
+		r.Users = users
+
+		groups := r.Groups[:0]
+		for _, group := range r.Groups {
+			if !containsFold(change.RemoveGroups, group) {
+				groups = append(groups, group)
+			}
+		}
+		for _, group := range change.AddGroups {
+			if group = strings.TrimSpace(group); group != "" && !containsFold(groups, group) {
+				groups = append(groups, group)
+			}
+		}
+		r.Groups = groups
+	}
+
+	return s.SetRestrictions(ctx, id, current)
+}
+
+// SetRestrictions replaces all restrictions on a page. Operations that are
+// omitted or have no users and groups become unrestricted.
+func (s *Service) SetRestrictions(ctx context.Context, id string, restrictions []Restriction) ([]Restriction, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+
+	payload := make([]map[string]interface{}, 0, len(restrictions))
+	for _, r := range restrictions {
+		if len(r.Users) == 0 && len(r.Groups) == 0 {
+			continue
+		}
+		operation, err := NormalizeOperation(r.Operation)
+		if err != nil {
+			return nil, err
+		}
+
+		users := make([]map[string]string, 0, len(r.Users))
+		for _, user := range r.Users {
+			entry := map[string]string{"type": "known"}
+			if user.AccountID != "" {
+				entry["accountId"] = user.AccountID
+			} else {
+				entry["username"] = user.Username
+			}
+			users = append(users, entry)
+		}
+		groups := make([]map[string]string, 0, len(r.Groups))
+		for _, group := range r.Groups {
+			groups = append(groups, map[string]string{"type": "group", "name": group})
+		}
+
+		payload = append(payload, map[string]interface{}{
+			"operation": operation,
+			"restrictions": map[string]interface{}{
+				"user":  users,
+				"group": groups,
+			},
+		})
+	}
+
+	path := apiPath("content", id, "restriction")
+	if len(payload) == 0 {
+		if err := s.client.Delete(ctx, path); err != nil {
+			return nil, err
+		}
+		return restrictionResponse{}.restrictions(), nil
+	}
+
+	params := url.Values{}
+	params.Set("expand", "restrictions.user,restrictions.group")
+
+	var response restrictionResponse
+	if err := s.client.Put(ctx, path+"?"+params.Encode(), payload, &response); err != nil {
+		return nil, err
+	}
+
+	return response.restrictions(), nil
+}
+
+// CopyRestrictions applies the restrictions of one page to another. Nothing
+// is written when the source page is unrestricted.
+func (s *Service) CopyRestrictions(ctx context.Context, fromID, toID string) error {
+	restrictions, err := s.GetRestrictions(ctx, fromID)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range restrictions {
+		if len(r.Users) > 0 || len(r.Groups) > 0 {
+			_, err := s.SetRestrictions(ctx, toID, restrictions)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckPermission reports whether a user or group may perform an operation
+// on a page. It asks Confluence's permission check endpoint where available
+// and otherwise evaluates the restrictions on the page and, for view, its
+// ancestors; space permissions are not considered in that case.
+func (s *Service) CheckPermission(ctx context.Context, id, subjectType, subject, operation string) (*PermissionCheck, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+	if subjectType != "user" && subjectType != "group" {
+		return nil, fmt.Errorf("confluence: invalid subject type %q (expected user or group)", subjectType)
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("confluence: %s required", subjectType)
+	}
+	operation, err := NormalizeOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"subject":   map[string]string{"type": subjectType, "identifier": subject},
+		"operation": operation,
+	}
+	var response struct {
+		HasPermission bool `json:"hasPermission"`
+		Errors        []struct {
+			Message struct {
+				Translation string `json:"translation"`
+			} `json:"message"`
+		} `json:"errors"`
+	}
+	err = s.client.Post(ctx, apiPath("content", id, "permission", "check"), payload, &response)
+	switch {
+	case err == nil:
+		check := &PermissionCheck{Allowed: response.HasPermission}
+		if len(response.Errors) > 0 {
+			check.Reason = response.Errors[0].Message.Translation
+		}
+		return check, nil
+	case atlassian.IsStatus(err, http.StatusNotFound), atlassian.IsStatus(err, http.StatusMethodNotAllowed):
+		return s.checkRestrictions(ctx, id, subjectType, subject, operation)
+	default:
+		return nil, err
+	}
+}
+
+// checkRestrictions evaluates page restrictions for CheckPermission.
+func (s *Service) checkRestrictions(ctx context.Context, id, subjectType, subject, operation string) (*PermissionCheck, error) {
+	pages := []string{id}
+	if operation == RestrictionView {
+		ancestors, err := s.GetAncestors(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, ancestor := range ancestors {
+			pages = append(pages, ancestor.ID)
+		}
+	}
+
+	var groups []string
+	if subjectType == "group" {
+		groups = []string{subject}
+	}
+	groupsLoaded := subjectType == "group"
+
+	for _, pageID := range pages {
+		restrictions, err := s.GetRestrictions(ctx, pageID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range restrictions {
+			if r.Operation != operation || (len(r.Users) == 0 && len(r.Groups) == 0) {
+				continue
+			}
+			if subjectType == "user" && hasUser(r.Users, subject) {
+				continue
+			}
+			if len(r.Groups) > 0 && !groupsLoaded {
+				if groups, err = s.userGroups(ctx, subject); err != nil {
+					return nil, err
+				}
+				groupsLoaded = true
+			}
+			if intersectsFold(r.Groups, groups) {
+				continue
+			}
+			return &PermissionCheck{
+				Reason: fmt.Sprintf("%s %s is not in the %s restrictions of page %s", subjectType, subject, operationName(operation), pageID),
+			}, nil
+		}
+	}
+
+	return &PermissionCheck{
+		Allowed: true,
+		Reason:  "page restrictions allow it; space permissions were not checked",
+	}, nil
+}
+
+// userGroups lists the groups a user belongs to.
+func (s *Service) userGroups(ctx context.Context, user string) ([]string, error) {
+	params := url.Values{}
+	if looksLikeAccountID(user) {
+		params.Set("accountId", user)
+	} else {
+		params.Set("username", user)
+	}
+	params.Set("limit", "200")
+
+	var response struct {
+		Results []struct {
+			Name string `json:"name"`
+		} `json:"results"`
+	}
+	if err := s.client.Get(ctx, apiPath("user", "memberof")+"?"+params.Encode(), &response); err != nil {
+		return nil, err
+	}
+
+	groups := make([]string, 0, len(response.Results))
+	for _, group := range response.Results {
+		groups = append(groups, group.Name)
+	}
+	return groups, nil
+}
+
+// NormalizeOperation maps view/edit (or read/update) to the restriction
+// operation names Confluence uses.
+func NormalizeOperation(operation string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(operation)) {
+	case "view", RestrictionView:
+		return RestrictionView, nil
+	case "edit", RestrictionEdit:
+		return RestrictionEdit, nil
+	default:
+		return "", fmt.Errorf("confluence: invalid restriction operation %q (expected view or edit)", operation)
+	}
+}
+
+func operationName(operation string) string {
+	if operation == RestrictionEdit {
+		return "edit"
+	}
+	return "view"
+}
+
+// newRestrictionUser builds a user reference from an account ID (Cloud) or a
+// username (Data Center).
+func newRestrictionUser(id string) User {
+	if looksLikeAccountID(id) {
+		return User{AccountID: id}
+	}
+	return User{Username: id}
+}
+
+// looksLikeAccountID reports whether id has the shape of a Cloud account ID:
+// either "<number>:<uuid>" or a 24 character hex string.
+func looksLikeAccountID(id string) bool {
+	if prefix, _, ok := strings.Cut(id, ":"); ok {
+		return isNumeric(prefix)
+	}
+	if len(id) != 24 {
+		return false
+	}
+	for _, r := range id {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasUser(users []User, id string) bool {
+	for _, user := range users {
+		if (user.AccountID != "" && user.AccountID == id) || (user.Username != "" && strings.EqualFold(user.Username, id)) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func intersectsFold(a, b []string) bool {
+	for _, value := range b {
+		if containsFold(a, value) {
+			return true
+		}
+	}
+	return false
+}
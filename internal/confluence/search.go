@@ -3,8 +3,10 @@ package confluence
 import (
 	"context"
 	"fmt"
+	"html"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // SearchContent performs a CQL search across content.
@@ -37,3 +39,112 @@ func (s *Service) SearchContent(ctx context.Context, cql string, limit int) ([]C
 
 	return response.Results, nil
 }
+
+var defaultSearchExpand = []string{"space", "version", "history.lastUpdated", "ancestors"}
+
+// Search runs a CQL query through the /search endpoint, which returns
+// highlighted excerpts and container details without fetching page bodies.
+// Excerpt highlights are rendered as Markdown bold and stripped from titles.
+func (s *Service) Search(ctx context.Context, query SearchQuery) (*SearchPage, error) {
+	if strings.TrimSpace(query.CQL) == "" {
+		return nil, fmt.Errorf("confluence: cql required")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 25
+	}
+
+	expand := query.Expand
+	if len(expand) == 0 {
+		expand = defaultSearchExpand
+	}
+	expansions := make([]string, 0, len(expand))
+	for _, field := range expand {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !strings.HasPrefix(field, "content.") {
+			field = "content." + field
+		}
+		expansions = append(expansions, field)
+	}
+
+	params := url.Values{}
+	params.Set("cql", query.CQL)
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("excerpt", "highlight")
+	if query.Cursor != "" {
+		params.Set("cursor", query.Cursor)
+	} else if query.Start > 0 {
+		params.Set("start", strconv.Itoa(query.Start))
+	}
+	if len(expansions) > 0 {
+		params.Set("expand", strings.Join(expansions, ","))
+	}
+
+	var response struct {
+		Results   []SearchResult `json:"results"`
+		Start     int            `json:"start"`
+		Size      int            `json:"size"`
+		TotalSize int            `json:"totalSize"`
+		Links     struct {
+			Next string `json:"next"`
+		} `json:"_links"`
+	}
+	if err := s.client.Get(ctx, apiPath("search")+"?"+params.Encode(), &response); err != nil {
+		return nil, err
+	}
+
+	page := &SearchPage{
+		Results:   response.Results,
+		Start:     response.Start,
+		TotalSize: response.TotalSize,
+	}
+	for i := range page.Results {
+		page.Results[i].Excerpt = cleanExcerpt(page.Results[i].Excerpt)
+		if page.Results[i].Title == "" && page.Results[i].Content != nil {
+			page.Results[i].Title = page.Results[i].Content.Title
+		}
+		page.Results[i].Title = html.UnescapeString(titleReplacer.Replace(page.Results[i].Title))
+		page.Results[i].SpaceKey = spaceKeyFromURL(page.Results[i].Container.DisplayURL)
+		if content := page.Results[i].Content; content != nil && content.Space != nil && content.Space.Key != "" {
+			page.Results[i].SpaceKey = content.Space.Key
+		}
+	}
+
+	if response.Links.Next != "" {
+		page.HasMore = true
+		page.NextStart = response.Start + response.Size
+		if next, err := url.Parse(response.Links.Next); err == nil {
+			page.NextCursor = next.Query().Get("cursor")
+		}
+	}
+
+	return page, nil
+}
+
+var (
+	excerptReplacer = strings.NewReplacer("@@@hl@@@", "**", "@@@endhl@@@", "**")
+	titleReplacer   = strings.NewReplacer("@@@hl@@@", "", "@@@endhl@@@", "")
+)
+
+// cleanExcerpt turns highlight markers into Markdown bold, decodes HTML
+// entities and collapses whitespace.
+func cleanExcerpt(excerpt string) string {
+	excerpt = html.UnescapeString(excerptReplacer.Replace(excerpt))
+	return strings.Join(strings.Fields(excerpt), " ")
+}
+
+// spaceKeyFromURL extracts the space key from a container display URL such
+// as /spaces/KEY or /display/KEY.
+func spaceKeyFromURL(displayURL string) string {
+	parts := strings.Split(strings.Trim(displayURL, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "spaces" || parts[i] == "display" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,280 @@
+package confluence
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Section edit modes accepted by ApplySectionEdit.
+const (
+	SectionAppend            = "append"
+	SectionPrepend           = "prepend"
+	SectionReplace           = "replace"
+	SectionInsertAfterAnchor = "insert_after_anchor"
+)
+
+// SectionEdit describes a localized change to a page body. Append and prepend
+// apply to the whole page, or to the section under Heading when set. Replace
+// swaps the content under Heading (up to the next heading of the same or a
+// higher level). InsertAfterAnchor places Content after the block holding the
+// named anchor macro.
+type SectionEdit struct {
+	Mode    string
+	Heading string
+	Anchor  string
+	Content string
+}
+
+// storageBlock is a block-level element in the page flow with its byte range
+// in the original storage.
+type storageBlock struct {
+	start, end int
+	container  int
+	heading    int
+	text       string
+	anchors    []string
+}
+
+// flowContainers are layout wrappers whose children are treated as part of
+// the page flow.
+var flowContainers = map[string]bool{
+	"ac:layout":         true,
+	"ac:layout-section": true,
+	"ac:layout-cell":    true,
+}
+
+// EditSection applies a section edit to the current version of a page.
+// Everything outside the edited range is written back byte-for-byte.
+func (s *Service) EditSection(ctx context.Context, id string, edit SectionEdit, expectedVersion int) (*Content, error) {
+	if err := validateSectionEdit(edit); err != nil {
+		return nil, err
+	}
+
+	return s.EditPage(ctx, id, PageEdit{
+		ExpectedVersion: expectedVersion,
+		Apply: func(current string) (string, error) {
+			return ApplySectionEdit(current, edit)
+		},
+	})
+}
+
+// ApplySectionEdit returns storage with edit applied. Only the targeted range
+// changes; the rest of the document is preserved exactly.
+func ApplySectionEdit(storage string, edit SectionEdit) (string, error) {
+	if err := validateSectionEdit(edit); err != nil {
+		return "", err
+	}
+
+	if edit.Heading == "" && edit.Mode == SectionAppend {
+		return storage + edit.Content, nil
+	}
+	if edit.Heading == "" && edit.Mode == SectionPrepend {
+		return edit.Content + storage, nil
+	}
+
+	blocks, err := storageBlocks(storage)
+	if err != nil {
+		return "", err
+	}
+
+	if edit.Mode == SectionInsertAfterAnchor {
+		for _, block := range blocks {
+			for _, anchor := range block.anchors {
+				if strings.EqualFold(anchor, edit.Anchor) {
+					return splice(storage, block.end, block.end, edit.Content), nil
+				}
+			}
+		}
+		return "", fmt.Errorf("confluence: anchor %q not found", edit.Anchor)
+	}
+
+	idx := -1
+	for i, block := range blocks {
+		if block.heading > 0 && strings.EqualFold(block.text, normalizeText(edit.Heading)) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		var headings []string
+		for _, block := range blocks {
+			if block.heading > 0 {
+				headings = append(headings, fmt.Sprintf("%q", block.text))
+			}
+		}
+		if len(headings) == 0 {
+			return "", fmt.Errorf("confluence: heading %q not found; page has no headings", edit.Heading)
+		}
+		return "", fmt.Errorf("confluence: heading %q not found; available: %s", edit.Heading, strings.Join(headings, ", "))
+	}
+
+	heading := blocks[idx]
+	sectionEnd := -1
+	for _, block := range blocks[idx+1:] {
+		if block.container != heading.container {
+			continue
+		}
+		if block.heading > 0 && block.heading <= heading.heading {
+			sectionEnd = block.start
+			break
+		}
+	}
+	if sectionEnd < 0 {
+		sectionEnd = containerEnd(blocks, idx, len(storage))
+	}
+
+	switch edit.Mode {
+	case SectionPrepend:
+		return splice(storage, heading.end, heading.end, edit.Content), nil
+	case SectionAppend:
+		return splice(storage, sectionEnd, sectionEnd, edit.Content), nil
+	default:
+		return splice(storage, heading.end, sectionEnd, edit.Content), nil
+	}
+}
+
+func validateSectionEdit(edit SectionEdit) error {
+	switch edit.Mode {
+	case SectionAppend, SectionPrepend:
+	case SectionReplace:
+		if edit.Heading == "" {
+			return fmt.Errorf("confluence: heading required to replace a section")
+		}
+	case SectionInsertAfterAnchor:
+		if edit.Anchor == "" {
+			return fmt.Errorf("confluence: anchor required")
+		}
+	default:
+		return fmt.Errorf("confluence: invalid section edit mode %q (expected append, prepend, replace or insert_after_anchor)", edit.Mode)
+	}
+
+	if edit.Mode != SectionReplace && edit.Content == "" {
+		return fmt.Errorf("confluence: content required")
+	}
+	if err := ValidateStorage(edit.Content); err != nil {
+		return fmt.Errorf("confluence: content is not valid storage format: %w", err)
+	}
+
+	return nil
+}
+
+// containerEnd returns the offset just past the last block sharing the
+// container of blocks[idx], or fallback when the block is at the top level.
+func containerEnd(blocks []storageBlock, idx, fallback int) int {
+	container := blocks[idx].container
+	if container == 0 {
+		return fallback
+	}
+	end := blocks[idx].end
+	for _, block := range blocks[idx+1:] {
+		if block.container == container {
+			end = block.end
+		}
+	}
+	return end
+}
+
+func splice(storage string, start, end int, content string) string {
+	return storage[:start] + content + storage[end:]
+}
+
+// storageBlocks scans storage for block-level elements in the page flow,
+// descending into layout containers, and records their byte ranges, heading
+// levels and anchor macros.
+func storageBlocks(storage string) ([]storageBlock, error) {
+	decoder := newStorageDecoder(storage)
+	offset := len(storageRoot)
+
+	type frame struct {
+		container int // non-zero (or root) when children are flow blocks
+		flow      bool
+	}
+
+	stack := []frame{}
+	nextContainer := 1
+	var blocks []storageBlock
+	var current *storageBlock
+	var text strings.Builder
+	anchorDepth := 0
+	var anchorText strings.Builder
+
+	for {
+		start := int(decoder.InputOffset()) - offset
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("confluence: parse storage: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := elementName(t.Name)
+			if len(stack) == 0 {
+				stack = append(stack, frame{container: 0, flow: true})
+				continue
+			}
+
+			parent := stack[len(stack)-1]
+			if current == nil && parent.flow {
+				if flowContainers[name] {
+					stack = append(stack, frame{container: nextContainer, flow: true})
+					nextContainer++
+					continue
+				}
+				current = &storageBlock{start: start, container: parent.container, heading: headingLevel(name)}
+				text.Reset()
+			}
+			stack = append(stack, frame{})
+
+			if name == "ac:structured-macro" && attr(t, "ac:name") == "anchor" && anchorDepth == 0 {
+				anchorDepth = len(stack)
+				anchorText.Reset()
+			}
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+
+			if anchorDepth > 0 && len(stack) < anchorDepth {
+				if current != nil {
+					current.anchors = append(current.anchors, normalizeText(anchorText.String()))
+				}
+				anchorDepth = 0
+			}
+
+			if current != nil && len(stack) > 0 && stack[len(stack)-1].flow {
+				current.end = int(decoder.InputOffset()) - offset
+				if current.heading > 0 {
+					current.text = normalizeText(text.String())
+				}
+				blocks = append(blocks, *current)
+				current = nil
+			}
+
+		case xml.CharData:
+			if current != nil && current.heading > 0 {
+				text.Write(t)
+			}
+			if anchorDepth > 0 {
+				anchorText.Write(t)
+			}
+		}
+	}
+
+	return blocks, nil
+}
+
+func headingLevel(name string) int {
+	if len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6' {
+		return int(name[1] - '0')
+	}
+	return 0
+}
+
+func normalizeText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
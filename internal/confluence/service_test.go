@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
 	"github.com/ylchen07/atlassian-mcp/internal/config"
@@ -408,6 +412,1371 @@ func TestGetPageValidation(t *testing.T) {
 	}
 }
 
+func TestFindPage(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/rest/api/content") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+
+		query := req.URL.Query()
+		if query.Get("spaceKey") != "ENG" || query.Get("title") != "Release Plan" || query.Get("type") != "page" {
+			t.Fatalf("unexpected query: %s", req.URL.RawQuery)
+		}
+
+		body := `{"results":[]}`
+		if query.Get("title") == "Release Plan" {
+			body = `{"results":[{"id":"42","title":"Release Plan"}]}`
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	page, err := service.FindPage(context.Background(), "ENG", "Release Plan", nil)
+	if err != nil {
+		t.Fatalf("FindPage error: %v", err)
+	}
+
+	if page.ID != "42" {
+		t.Fatalf("expected page 42, got %s", page.ID)
+	}
+}
+
+func TestParsePageURL(t *testing.T) {
+	t.Parallel()
+
+	base := "https://example.atlassian.net/wiki"
+
+	tests := []struct {
+		name string
+		url  string
+		want PageRef
+	}{
+		{"cloud", "https://example.atlassian.net/wiki/spaces/ENG/pages/12345/Release+Plan", PageRef{ID: "12345", SpaceKey: "ENG"}},
+		{"cloud edit", "https://example.atlassian.net/wiki/spaces/ENG/pages/edit-v2/12345", PageRef{ID: "12345", SpaceKey: "ENG"}},
+		{"relative", "/wiki/spaces/ENG/pages/12345", PageRef{ID: "12345", SpaceKey: "ENG"}},
+		{"display", "https://example.atlassian.net/wiki/display/ENG/Release+Plan", PageRef{SpaceKey: "ENG", Title: "Release Plan"}},
+		{"display encoded", "/display/ENG/Q%26A%20Notes", PageRef{SpaceKey: "ENG", Title: "Q&A Notes"}},
+		{"viewpage", "https://example.atlassian.net/wiki/pages/viewpage.action?pageId=987", PageRef{ID: "987"}},
+		{"tiny", "https://example.atlassian.net/wiki/x/QQAB", PageRef{ID: "65601"}},
+		{"cloud blog", "https://example.atlassian.net/wiki/spaces/ENG/blog/2024/03/15/45678/Weekly+Update", PageRef{ID: "45678", SpaceKey: "ENG"}},
+		{"display blog", "/display/ENG/2024/03/15/Weekly+Update", PageRef{SpaceKey: "ENG", Title: "Weekly Update", PostingDay: "2024-03-15"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePageURL(base, tt.url)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%s: expected %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+
+	if _, err := ParsePageURL(base, "https://other.example.com/wiki/spaces/ENG/pages/1"); err == nil {
+		t.Fatal("expected error for foreign host")
+	}
+	if _, err := ParsePageURL(base, "https://example.atlassian.net/wiki/spaces/ENG/overview"); err == nil {
+		t.Fatal("expected error for non-page url")
+	}
+}
+
+func TestGetChildrenPaginates(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/rest/api/content/1/child/page") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+
+		var body string
+		switch req.URL.Query().Get("start") {
+		case "0":
+			body = `{"results":[{"id":"2","title":"A"},{"id":"3","title":"B"}],"size":2,"_links":{"next":"/rest/api/content/1/child/page?start=2"}}`
+		case "2":
+			body = `{"results":[{"id":"4","title":"C"}],"size":1,"_links":{}}`
+		default:
+			t.Fatalf("unexpected start: %s", req.URL.Query().Get("start"))
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	children, err := service.GetChildren(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetChildren error: %v", err)
+	}
+
+	if len(children) != 3 || children[2].ID != "4" {
+		t.Fatalf("unexpected children: %+v", children)
+	}
+}
+
+func TestGetDescendants(t *testing.T) {
+	t.Parallel()
+
+	tree := map[string]string{
+		"1": `[{"id":"2","title":"A"},{"id":"3","title":"B"}]`,
+		"2": `[{"id":"4","title":"A1"}]`,
+		"3": `[]`,
+		"4": `[{"id":"5","title":"A1a"}]`,
+	}
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		id := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/rest/api/content/"), "/child/page")
+		results, ok := tree[id]
+		if !ok {
+			t.Fatalf("unexpected request for %s", id)
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"results":` + results + `,"_links":{}}`)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+
+	nodes, truncated, err := service.GetDescendants(context.Background(), "1", DescendantOptions{MaxDepth: 2})
+	if err != nil {
+		t.Fatalf("GetDescendants error: %v", err)
+	}
+	if truncated {
+		t.Fatal("did not expect truncation")
+	}
+
+	var got []string
+	for _, node := range nodes {
+		got = append(got, node.ID+"@"+strconv.Itoa(node.Depth)+"<"+node.ParentID)
+	}
+	want := "2@1<1,4@2<2,3@1<1"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("expected %s, got %s", want, strings.Join(got, ","))
+	}
+
+	nodes, truncated, err = service.GetDescendants(context.Background(), "1", DescendantOptions{MaxDepth: 3, Limit: 2})
+	if err != nil {
+		t.Fatalf("GetDescendants error: %v", err)
+	}
+	if !truncated || len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes and truncation, got %d (truncated=%v)", len(nodes), truncated)
+	}
+}
+
+func TestRestorePage(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		switch req.Method {
+		case http.MethodGet:
+			if req.URL.Query().Get("status") != "trashed" {
+				t.Fatalf("expected trashed status lookup, got %s", req.URL.RawQuery)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"id":"7","type":"page","status":"trashed","title":"Old","version":{"number":4}}`)),
+				Header:     make(http.Header),
+			}, nil
+		case http.MethodPut:
+			var payload map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload["status"] != "current" || payload["title"] != "Old" {
+				t.Fatalf("unexpected payload: %v", payload)
+			}
+			if version := payload["version"].(map[string]interface{})["number"]; version != float64(5) {
+				t.Fatalf("expected version 5, got %v", version)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"id":"7","status":"current","title":"Old","version":{"number":5}}`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+		t.Fatalf("unexpected method %s", req.Method)
+		return nil, nil
+	})
+
+	service := NewService(client)
+	page, err := service.RestorePage(context.Background(), "7")
+	if err != nil {
+		t.Fatalf("RestorePage error: %v", err)
+	}
+	if page.Status != "current" {
+		t.Fatalf("expected current status, got %s", page.Status)
+	}
+}
+
+func TestMovePageToSpace(t *testing.T) {
+	t.Parallel()
+
+	var moved bool
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/rest/api/space/OPS"):
+			body = `{"key":"OPS","homepage":{"id":"100","title":"Ops Home"}}`
+		case req.Method == http.MethodPut && strings.HasSuffix(req.URL.Path, "/rest/api/content/7/move/append/100"):
+			moved = true
+			body = `{"pageId":"7"}`
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/rest/api/content/7"):
+			body = `{"id":"7","title":"Runbook","space":{"key":"OPS"},"ancestors":[{"id":"100","title":"Ops Home"}]}`
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	page, err := service.MovePage(context.Background(), "7", MoveInput{SpaceKey: "OPS"})
+	if err != nil {
+		t.Fatalf("MovePage error: %v", err)
+	}
+	if !moved {
+		t.Fatal("expected move request")
+	}
+	if page.Space == nil || page.Space.Key != "OPS" || len(page.Ancestors) != 1 {
+		t.Fatalf("unexpected moved page: %+v", page)
+	}
+
+	if _, err := service.MovePage(context.Background(), "7", MoveInput{SpaceKey: "OPS", Position: "before"}); err == nil {
+		t.Fatal("expected error for before without target")
+	}
+	if _, err := service.MovePage(context.Background(), "7", MoveInput{TargetID: "8", SpaceKey: "OPS"}); err == nil {
+		t.Fatal("expected error for both target and space")
+	}
+}
+
+func TestListVersionsAndGetPageAtVersion(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/rest/api/content/9/version"):
+			body = `{"results":[{"number":2,"when":"2024-03-02T10:00:00.000Z","by":{"displayName":"Ann"}},{"number":3,"when":"2024-03-09T10:00:00.000Z"},{"number":1,"when":"2024-03-01T10:00:00.000Z"}],"_links":{}}`
+		case strings.HasSuffix(req.URL.Path, "/rest/api/content/9"):
+			query := req.URL.Query()
+			if query.Get("status") != "historical" || query.Get("version") != "2" {
+				t.Fatalf("unexpected query: %s", req.URL.RawQuery)
+			}
+			body = `{"id":"9","title":"Doc","version":{"number":2},"body":{"storage":{"value":"<p>v2</p>"}}}`
+		default:
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+
+	versions, err := service.ListVersions(context.Background(), "9", 0)
+	if err != nil {
+		t.Fatalf("ListVersions error: %v", err)
+	}
+	if len(versions) != 3 || versions[0].Number != 3 || versions[2].Number != 1 {
+		t.Fatalf("expected versions newest first, got %+v", versions)
+	}
+
+	at := VersionAt(versions, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC))
+	if at == nil || at.Number != 2 {
+		t.Fatalf("expected version 2 to be current on 2024-03-05, got %+v", at)
+	}
+	if VersionAt(versions, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) != nil {
+		t.Fatal("expected no version before the page existed")
+	}
+
+	page, err := service.GetPageAtVersion(context.Background(), "9", 2)
+	if err != nil {
+		t.Fatalf("GetPageAtVersion error: %v", err)
+	}
+	if page.Body.Storage.Value != "<p>v2</p>" {
+		t.Fatalf("unexpected body: %s", page.Body.Storage.Value)
+	}
+}
+
+func TestStorageToText(t *testing.T) {
+	t.Parallel()
+
+	storage := `<h2>Plan</h2><p>Ship <strong>v2</strong> &amp; see <ac:link><ri:page ri:content-title="Roadmap"/></ac:link>.</p>` +
+		`<ul><li>one</li><li>two<ul><li>nested</li></ul></li></ul>` +
+		`<table><tbody><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2</td></tr></tbody></table>` +
+		`<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter>` +
+		`<ac:plain-text-body><![CDATA[x := 1
+y := 2]]></ac:plain-text-body></ac:structured-macro>` +
+		`<p>line<br/>break</p><ac:image><ri:attachment ri:filename="diagram.png"/></ac:image>`
+
+	want := strings.Join([]string{
+		"## Plan",
+		"Ship v2 & see Roadmap.",
+		"- one",
+		"- two",
+		"  - nested",
+		"A | B",
+		"1 | 2",
+		"x := 1",
+		"y := 2",
+		"line",
+		"break",
+		"[image: diagram.png]",
+	}, "\n")
+
+	if got := StorageToText(storage); got != want {
+		t.Fatalf("unexpected text:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk"
+
+	want := strings.Join([]string{
+		"--- v1",
+		"+++ v2",
+		"@@ -1,5 +1,5 @@",
+		" a",
+		"-b",
+		"+B",
+		" c",
+		" d",
+		" e",
+		"@@ -8,3 +8,4 @@",
+		" h",
+		" i",
+		" j",
+		"+k",
+		"",
+	}, "\n")
+
+	if got := UnifiedDiff("v1", "v2", from, to); got != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	if added, removed := DiffStats(from, to); added != 2 || removed != 1 {
+		t.Fatalf("expected +2/-1, got +%d/-%d", added, removed)
+	}
+
+	if got := UnifiedDiff("v1", "v2", from, from); got != "" {
+		t.Fatalf("expected empty diff for identical input, got %q", got)
+	}
+}
+
+func TestEditPageRetriesOnConflict(t *testing.T) {
+	t.Parallel()
+
+	gets, puts := 0, 0
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		switch req.Method {
+		case http.MethodGet:
+			gets++
+			version := 4 + gets // 5 on the first read, 6 after the conflict
+			body := fmt.Sprintf(`{"id":"3","title":"Kept Title","version":{"number":%d},"body":{"storage":{"value":"<p>old</p>"}}}`, version)
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+		case http.MethodPut:
+			puts++
+			var payload struct {
+				Title   string `json:"title"`
+				Version struct {
+					Number int `json:"number"`
+				} `json:"version"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload.Title != "Kept Title" {
+				t.Fatalf("expected title to be preserved, got %q", payload.Title)
+			}
+			if puts == 1 {
+				if payload.Version.Number != 6 {
+					t.Fatalf("expected version 6, got %d", payload.Version.Number)
+				}
+				return &http.Response{StatusCode: http.StatusConflict, Body: io.NopCloser(strings.NewReader("version conflict")), Header: make(http.Header)}, nil
+			}
+			if payload.Version.Number != 7 {
+				t.Fatalf("expected version 7 after retry, got %d", payload.Version.Number)
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"id":"3","title":"Kept Title","version":{"number":7}}`)), Header: make(http.Header)}, nil
+		}
+		t.Fatalf("unexpected method %s", req.Method)
+		return nil, nil
+	})
+
+	service := NewService(client)
+	updated, err := service.EditPage(context.Background(), "3", PageEdit{Body: "<p>new</p>"})
+	if err != nil {
+		t.Fatalf("EditPage error: %v", err)
+	}
+	if updated.Version.Number != 7 || puts != 2 {
+		t.Fatalf("expected version 7 after 2 writes, got %d after %d", updated.Version.Number, puts)
+	}
+}
+
+func TestEditPageExpectedVersionConflict(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("expected no write, got %s", req.Method)
+		}
+
+		body := `{"id":"3","title":"Doc","version":{"number":5},"body":{"storage":{"value":"<p>one</p><p>two</p>"}}}`
+		if req.URL.Query().Get("status") == "historical" {
+			body = `{"id":"3","title":"Doc","version":{"number":4},"body":{"storage":{"value":"<p>one</p>"}}}`
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})
+
+	service := NewService(client)
+	_, err := service.EditPage(context.Background(), "3", PageEdit{Body: "<p>mine</p>", ExpectedVersion: 4})
+
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if conflict.Expected != 4 || conflict.Current != 5 {
+		t.Fatalf("unexpected versions: %+v", conflict)
+	}
+	if !strings.Contains(conflict.Diff, "+two") {
+		t.Fatalf("expected diff to show the concurrent change, got:\n%s", conflict.Diff)
+	}
+}
+
+func TestApplySectionEdit(t *testing.T) {
+	t.Parallel()
+
+	doc := "<h1>Notes</h1>\n<p>intro&nbsp;<br>text</p>\n" +
+		"<h2>Week 1</h2><p>old</p>\n" +
+		`<h2>Changelog</h2><p>c1</p><p><ac:structured-macro ac:name="anchor"><ac:parameter ac:name="">latest</ac:parameter></ac:structured-macro></p>` + "\n" +
+		`<h1>Other</h1><ac:layout><ac:layout-section><ac:layout-cell><h3>Inner</h3><p>in</p></ac:layout-cell></ac:layout-section></ac:layout>`
+
+	tests := []struct {
+		name string
+		edit SectionEdit
+		old  string
+		new  string
+	}{
+		{"append page", SectionEdit{Mode: SectionAppend, Content: "<p>end</p>"}, "</ac:layout>", "</ac:layout><p>end</p>"},
+		{"prepend page", SectionEdit{Mode: SectionPrepend, Content: "<p>top</p>"}, "<h1>Notes</h1>", "<p>top</p><h1>Notes</h1>"},
+		{"append section", SectionEdit{Mode: SectionAppend, Heading: "changelog", Content: "<p>c2</p>"}, "\n<h1>Other</h1>", "\n<p>c2</p><h1>Other</h1>"},
+		{"prepend section", SectionEdit{Mode: SectionPrepend, Heading: "Week 1", Content: "<p>new</p>"}, "<h2>Week 1</h2>", "<h2>Week 1</h2><p>new</p>"},
+		{"replace section", SectionEdit{Mode: SectionReplace, Heading: "Week 1", Content: "<p>R</p>"}, "<p>old</p>\n", "<p>R</p>"},
+		{"anchor", SectionEdit{Mode: SectionInsertAfterAnchor, Anchor: "latest", Content: "<p>A</p>"}, "</ac:structured-macro></p>", "</ac:structured-macro></p><p>A</p>"},
+		{"layout", SectionEdit{Mode: SectionAppend, Heading: "Inner", Content: "<p>I</p>"}, "<p>in</p>", "<p>in</p><p>I</p>"},
+	}
+
+	for _, tt := range tests {
+		got, err := ApplySectionEdit(doc, tt.edit)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if want := strings.Replace(doc, tt.old, tt.new, 1); got != want {
+			t.Fatalf("%s: unexpected result:\n%s\nwant:\n%s", tt.name, got, want)
+		}
+	}
+
+	if _, err := ApplySectionEdit(doc, SectionEdit{Mode: SectionReplace, Heading: "Missing"}); err == nil || !strings.Contains(err.Error(), `"Changelog"`) {
+		t.Fatalf("expected error listing headings, got %v", err)
+	}
+	if _, err := ApplySectionEdit(doc, SectionEdit{Mode: SectionAppend, Content: "<p>unclosed"}); err == nil {
+		t.Fatal("expected error for malformed content")
+	}
+}
+
+func TestCreatePageWithLabels(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var payload struct {
+			Metadata struct {
+				Labels []Label `json:"labels"`
+			} `json:"metadata"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if len(payload.Metadata.Labels) != 2 || payload.Metadata.Labels[0].Name != "how-to" || payload.Metadata.Labels[1].Prefix != "global" {
+			t.Fatalf("unexpected labels: %+v", payload.Metadata.Labels)
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"id":"1","title":"Doc"}`)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	_, err := service.CreatePage(context.Background(), PageInput{
+		SpaceKey: "ENG",
+		Title:    "Doc",
+		Body:     "<p>x</p>",
+		Labels:   []string{"How To", "runbook", "how-to"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePage error: %v", err)
+	}
+
+	if _, err := NormalizeLabel("v1.2"); err == nil {
+		t.Fatal("expected error for label with a period")
+	}
+}
+
+func TestPopularLabels(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/rest/api/content/search") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		if cql := req.URL.Query().Get("cql"); !strings.Contains(cql, `space = "ENG"`) {
+			t.Fatalf("unexpected cql: %s", cql)
+		}
+
+		// The second page is only reachable through the cursor; an offset
+		// request would repeat the first page.
+		body := `{"results":[` +
+			`{"id":"1","metadata":{"labels":{"results":[{"name":"runbook"},{"name":"ops"}]}}},` +
+			`{"id":"2","metadata":{"labels":{"results":[{"name":"runbook"}]}}}],` +
+			`"_links":{"next":"/rest/api/content/search?cql=x&limit=50&cursor=abc"}}`
+		if cursor := req.URL.Query().Get("cursor"); cursor == "abc" {
+			if req.URL.Query().Get("start") != "" {
+				t.Fatalf("cursor requests must not send start: %s", req.URL.RawQuery)
+			}
+			body = `{"results":[{"id":"3"}],"_links":{}}`
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	popular, err := service.PopularLabels(context.Background(), "ENG", 10)
+	if err != nil {
+		t.Fatalf("PopularLabels error: %v", err)
+	}
+
+	if len(popular) != 2 || popular[0] != (LabelCount{Name: "runbook", Count: 2}) || popular[1] != (LabelCount{Name: "ops", Count: 1}) {
+		t.Fatalf("unexpected popular labels: %+v", popular)
+	}
+}
+
+func TestAddCommentReply(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/rest/api/content") {
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+
+		var payload struct {
+			Type      string            `json:"type"`
+			Container map[string]string `json:"container"`
+			Ancestors []map[string]string
+		}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload.Type != "comment" || payload.Container["id"] != "10" || payload.Container["type"] != "blogpost" || len(payload.Ancestors) != 1 || payload.Ancestors[0]["id"] != "77" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"id":"78","type":"comment","ancestors":[{"id":"77","type":"comment"}]}`)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	comment, err := service.AddComment(context.Background(), "10", TypeBlogPost, "77", "<p>Agreed</p>")
+	if err != nil {
+		t.Fatalf("AddComment error: %v", err)
+	}
+	if comment.ParentID() != "77" {
+		t.Fatalf("expected parent 77, got %q", comment.ParentID())
+	}
+}
+
+func TestListCommentsInline(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/rest/api/content/10/child/comment") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		query := req.URL.Query()
+		if query.Get("location") != "inline" || query.Get("depth") != "all" {
+			t.Fatalf("unexpected query: %s", req.URL.RawQuery)
+		}
+
+		body := `{"results":[{"id":"5","type":"comment","extensions":{"location":"inline","inlineProperties":{"originalSelection":"typo here"},"resolution":{"status":"open"}}}],"_links":{}}`
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	comments, err := service.ListComments(context.Background(), "10", CommentInline)
+	if err != nil {
+		t.Fatalf("ListComments error: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Extensions.InlineProperties.OriginalSelection != "typo here" || comments[0].Extensions.Resolution.Status != "open" {
+		t.Fatalf("unexpected comments: %+v", comments)
+	}
+}
+
+func TestUploadAttachmentNewVersion(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/rest/api/content/10/child/attachment"):
+			if req.URL.Query().Get("filename") != "arch.png" {
+				t.Fatalf("expected filename filter, got %s", req.URL.RawQuery)
+			}
+			body = `{"results":[{"id":"att5","title":"arch.png","version":{"number":1}}],"_links":{}}`
+		case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/rest/api/content/10/child/attachment/att5/data"):
+			if err := req.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parse multipart: %v", err)
+			}
+			if req.FormValue("comment") != "updated diagram" || req.FormValue("minorEdit") != "false" {
+				t.Fatalf("unexpected form fields: %v", req.MultipartForm.Value)
+			}
+			body = `{"id":"att5","title":"arch.png","version":{"number":2},"metadata":{"mediaType":"image/png"}}`
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	attachment, err := service.UploadAttachment(context.Background(), "10", AttachmentInput{
+		FileName: "arch.png",
+		Data:     []byte("png"),
+		Comment:  "updated diagram",
+	})
+	if err != nil {
+		t.Fatalf("UploadAttachment error: %v", err)
+	}
+	if attachment.Version.Number != 2 || attachment.MediaType() != "image/png" {
+		t.Fatalf("unexpected attachment: %+v", attachment)
+	}
+}
+
+func TestImageMarkup(t *testing.T) {
+	t.Parallel()
+
+	got := ImageMarkup("a&b.png", ImageOptions{Width: 600})
+	want := `<ac:image ac:width="600"><ri:attachment ri:filename="a&amp;b.png" /></ac:image>`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	got = ImageMarkup("arch.png", ImageOptions{Alt: "Architecture", PageTitle: "Design"})
+	want = `<ac:image ac:alt="Architecture"><ri:attachment ri:filename="arch.png"><ri:page ri:content-title="Design" /></ri:attachment></ac:image>`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestCreateBlogPost(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload["type"] != TypeBlogPost {
+			t.Fatalf("unexpected type: %v", payload["type"])
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"id":"7","type":"blogpost","title":"Weekly Update"}`)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	created, err := service.CreatePage(context.Background(), PageInput{
+		Type:     TypeBlogPost,
+		SpaceKey: "ENG",
+		Title:    "Weekly Update",
+		Body:     "<p>x</p>",
+	})
+	if err != nil {
+		t.Fatalf("CreatePage error: %v", err)
+	}
+	if created.Type != TypeBlogPost {
+		t.Fatalf("unexpected type: %s", created.Type)
+	}
+
+	if _, err := service.CreatePage(context.Background(), PageInput{Type: TypeBlogPost, SpaceKey: "ENG", Title: "x", Body: "<p/>", ParentID: "1"}); err == nil {
+		t.Fatal("expected error for blog post with a parent")
+	}
+	if _, err := service.CreatePage(context.Background(), PageInput{Type: "whiteboard", SpaceKey: "ENG", Title: "x", Body: "<p/>"}); err == nil {
+		t.Fatal("expected error for unknown content type")
+	}
+}
+
+func TestListBlogPosts(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/rest/api/content/search") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		want := `space = "ENG" AND type = "blogpost" AND created >= "2024-03-01" AND created < "2024-04-01" ORDER BY created DESC`
+		if cql := req.URL.Query().Get("cql"); cql != want {
+			t.Fatalf("unexpected cql: %s", cql)
+		}
+
+		body := `{"results":[{"id":"2","type":"blogpost","title":"Week 2","history":{"createdDate":"2024-03-11T09:00:00.000Z","createdBy":{"displayName":"Ada"}}}],` +
+			`"_links":{"next":"/rest/api/content/search?limit=50&cursor=next1"}}`
+		if req.URL.Query().Get("cursor") == "next1" {
+			body = `{"results":[{"id":"1","type":"blogpost","title":"Week 1"}],"_links":{}}`
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	posts, err := service.ListBlogPosts(context.Background(), BlogPostQuery{
+		SpaceKey: "ENG",
+		From:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("ListBlogPosts error: %v", err)
+	}
+
+	if len(posts) != 2 || posts[1].ID != "1" || posts[0].History == nil || posts[0].History.CreatedBy.DisplayName != "Ada" {
+		t.Fatalf("unexpected posts: %+v", posts)
+	}
+}
+
+func TestFillTemplate(t *testing.T) {
+	t.Parallel()
+
+	storage := `<at:declarations><at:string at:name="owner" /><at:list at:name="status"><at:option at:value="Proposed" /><at:option at:value="Accepted" /></at:list></at:declarations>` +
+		`<h1>Decision</h1><p>Owner: <at:var at:name="owner" />, status <at:var at:name="status" /></p><p><at:var at:name="context" at:rawxhtml="true" /></p>`
+
+	variables, err := TemplateVariables(storage)
+	if err != nil {
+		t.Fatalf("TemplateVariables error: %v", err)
+	}
+	if len(variables) != 3 || variables[1].Type != "list" || len(variables[1].Options) != 2 || variables[2].Name != "context" {
+		t.Fatalf("unexpected variables: %+v", variables)
+	}
+
+	got, err := FillTemplate(storage, map[string]string{"owner": "R&D", "status": "Accepted", "context": "<em>why</em>"})
+	if err != nil {
+		t.Fatalf("FillTemplate error: %v", err)
+	}
+	want := `<h1>Decision</h1><p>Owner: R&amp;D, status Accepted</p><p><em>why</em></p>`
+	if got != want {
+		t.Fatalf("unexpected body:\n%s", got)
+	}
+
+	if _, err := FillTemplate(storage, map[string]string{"status": "Accepted"}); err == nil || !strings.Contains(err.Error(), "context, owner") {
+		t.Fatalf("expected missing variables error, got %v", err)
+	}
+	if _, err := FillTemplate(storage, map[string]string{"owner": "x", "status": "Rejected", "context": ""}); err == nil {
+		t.Fatal("expected error for value outside list options")
+	}
+}
+
+func TestCreateFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/rest/api/template/42"):
+			body := `{"templateId":"42","name":"ADR","labels":[{"prefix":"global","name":"adr"}],"body":{"storage":{"value":"<p><at:var at:name=\"title\" /></p>"}}}`
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/rest/api/content"):
+			var payload struct {
+				Body struct {
+					Storage struct {
+						Value string `json:"value"`
+					} `json:"storage"`
+				} `json:"body"`
+				Metadata struct {
+					Labels []Label `json:"labels"`
+				} `json:"metadata"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload.Body.Storage.Value != "<p>Use Postgres</p>" {
+				t.Fatalf("unexpected body: %s", payload.Body.Storage.Value)
+			}
+			if len(payload.Metadata.Labels) != 2 || payload.Metadata.Labels[0].Name != "adr" {
+				t.Fatalf("unexpected labels: %+v", payload.Metadata.Labels)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"id":"9","title":"ADR 7"}`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		return nil, nil
+	})
+
+	service := NewService(client)
+	created, err := service.CreateFromTemplate(context.Background(), TemplateInput{
+		TemplateID: "42",
+		SpaceKey:   "ENG",
+		Title:      "ADR 7",
+		Variables:  map[string]string{"title": "Use Postgres"},
+		Labels:     []string{"database"},
+	})
+	if err != nil {
+		t.Fatalf("CreateFromTemplate error: %v", err)
+	}
+	if created.ID != "9" {
+		t.Fatalf("unexpected page: %+v", created)
+	}
+}
+
+func TestUpdateRestrictions(t *testing.T) {
+	t.Parallel()
+
+	current := `{"results":[` +
+		`{"operation":"read","restrictions":{"user":{"results":[]},"group":{"results":[]}}},` +
+		`{"operation":"update","restrictions":{"user":{"results":[{"accountId":"557058:aaa","displayName":"Ada"},{"accountId":"557058:bbb"}]},"group":{"results":[{"name":"eng"}]}}}]}`
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		switch req.Method {
+		case http.MethodGet:
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(current)),
+				Header:     make(http.Header),
+			}, nil
+		case http.MethodPut:
+			var payload []struct {
+				Operation    string `json:"operation"`
+				Restrictions struct {
+					User  []map[string]string `json:"user"`
+					Group []map[string]string `json:"group"`
+				} `json:"restrictions"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if len(payload) != 1 || payload[0].Operation != RestrictionEdit {
+				t.Fatalf("unexpected payload: %+v", payload)
+			}
+			users := payload[0].Restrictions.User
+			if len(users) != 2 || users[0]["accountId"] != "557058:aaa" || users[1]["username"] != "grace" {
+				t.Fatalf("unexpected users: %+v", users)
+			}
+			if groups := payload[0].Restrictions.Group; len(groups) != 2 || groups[1]["name"] != "sre" {
+				t.Fatalf("unexpected groups: %+v", groups)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"results":[]}`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		return nil, nil
+	})
+
+	service := NewService(client)
+	_, err := service.UpdateRestrictions(context.Background(), "1", RestrictionChange{
+		Operation:   "edit",
+		AddUsers:    []string{"grace"},
+		RemoveUsers: []string{"557058:bbb"},
+		AddGroups:   []string{"sre", "ENG"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateRestrictions error: %v", err)
+	}
+
+	if _, err := NormalizeOperation("delete"); err == nil {
+		t.Fatal("expected error for unknown operation")
+	}
+}
+
+func TestCheckPermissionFallsBackToRestrictions(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		body := ""
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/permission/check"):
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("not found")), Header: make(http.Header)}, nil
+		case strings.HasSuffix(req.URL.Path, "/rest/api/content/2/restriction"):
+			body = `{"results":[{"operation":"update","restrictions":{"user":{"results":[{"username":"ada"}]},"group":{"results":[{"name":"writers"}]}}}]}`
+		case strings.HasSuffix(req.URL.Path, "/rest/api/user/memberof"):
+			if req.URL.Query().Get("username") != "grace" {
+				t.Fatalf("unexpected memberof query: %s", req.URL.RawQuery)
+			}
+			body = `{"results":[{"name":"writers"}]}`
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	for user, want := range map[string]bool{"ada": true, "grace": true} {
+		check, err := service.CheckPermission(context.Background(), "2", "user", user, "edit")
+		if err != nil {
+			t.Fatalf("CheckPermission error: %v", err)
+		}
+		if check.Allowed != want {
+			t.Fatalf("%s: expected allowed=%v, got %+v", user, want, check)
+		}
+	}
+
+	check, err := service.CheckPermission(context.Background(), "2", "group", "readers", "edit")
+	if err != nil {
+		t.Fatalf("CheckPermission error: %v", err)
+	}
+	if check.Allowed || !strings.Contains(check.Reason, "edit restrictions") {
+		t.Fatalf("expected denial, got %+v", check)
+	}
+}
+
+func TestCopyPageTree(t *testing.T) {
+	t.Parallel()
+
+	var created []string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		respond := func(body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		}
+
+		path := strings.TrimPrefix(req.URL.Path, "/rest/api/")
+		switch {
+		case req.Method == http.MethodPost && path == "content":
+			var payload struct {
+				Title     string `json:"title"`
+				Ancestors []struct {
+					ID string `json:"id"`
+				} `json:"ancestors"`
+				Body struct {
+					Storage struct {
+						Value string `json:"value"`
+					} `json:"storage"`
+				} `json:"body"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			id := fmt.Sprintf("10%d", len(created))
+			created = append(created, payload.Title+" <- "+payload.Ancestors[0].ID+": "+payload.Body.Storage.Value)
+			return respond(fmt.Sprintf(`{"id":%q,"title":%q}`, id, payload.Title))
+		case path == "content/1" && req.URL.Query().Get("expand") == "space":
+			return respond(`{"id":"1","title":"Q1 Plan","space":{"key":"PLAN"}}`)
+		case path == "content/50":
+			return respond(`{"id":"50","title":"Planning","space":{"key":"PLAN"}}`)
+		case path == "content/1":
+			return respond(`{"id":"1","title":"Q1 Plan","body":{"storage":{"value":"<p>See <ac:link><ri:page ri:content-title=\"Q1 Goals\" /></ac:link></p>"}}}`)
+		case path == "content/2":
+			return respond(`{"id":"2","title":"Q1 Goals","body":{"storage":{"value":"<p>Goals</p>"}}}`)
+		case path == "content/1/child/page":
+			return respond(`{"results":[{"id":"2","title":"Q1 Goals"}],"_links":{}}`)
+		case path == "content/2/child/page":
+			return respond(`{"results":[],"_links":{}}`)
+		}
+		t.Fatalf("unexpected request: %s %s?%s", req.Method, req.URL.Path, req.URL.RawQuery)
+		return nil, nil
+	})
+
+	service := NewService(client)
+	var progress []int
+	copies, err := service.CopyPage(context.Background(), "1", CopyOptions{
+		TargetParentID:     "50",
+		TitleSuffix:        " (copy)",
+		IncludeDescendants: true,
+		Progress: func(done, total int, _ CopiedPage) {
+			progress = append(progress, done*10+total)
+		},
+	})
+	if err != nil {
+		t.Fatalf("CopyPage error: %v", err)
+	}
+
+	if len(copies) != 2 || copies[1].SourceID != "2" || copies[1].ParentID != copies[0].ID {
+		t.Fatalf("unexpected copies: %+v", copies)
+	}
+	want := []string{
+		`Q1 Plan (copy) <- 50: <p>See <ac:link><ri:page ri:content-title="Q1 Goals (copy)" /></ac:link></p>`,
+		`Q1 Goals (copy) <- 100: <p>Goals</p>`,
+	}
+	if strings.Join(created, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected pages:\n%s", strings.Join(created, "\n"))
+	}
+	if len(progress) != 2 || progress[0] != 12 || progress[1] != 22 {
+		t.Fatalf("unexpected progress: %v", progress)
+	}
+
+	if _, err := service.CopyPage(context.Background(), "1", CopyOptions{TargetParentID: "50"}); err == nil {
+		t.Fatal("expected error copying within the same space without a title change")
+	}
+}
+
+func TestGetSpaceWithoutPermissionAccess(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Query().Get("expand"), "permissions") {
+			return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader("forbidden")), Header: make(http.Header)}, nil
+		}
+		body := `{"id":7,"key":"ENG","name":"Engineering","type":"global","status":"current","homepage":{"id":"99","title":"Engineering Home"}}`
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	space, err := service.GetSpace(context.Background(), "ENG")
+	if err != nil {
+		t.Fatalf("GetSpace error: %v", err)
+	}
+	if space.Type != SpaceGlobal || space.Status != SpaceCurrent || space.Homepage == nil || space.Homepage.ID != "99" {
+		t.Fatalf("unexpected space: %+v", space)
+	}
+}
+
+func TestSummarizePermissions(t *testing.T) {
+	t.Parallel()
+
+	var permissions []SpacePermission
+	raw := `[` +
+		`{"operation":{"operation":"read","targetType":"space"},"subjects":{"group":{"results":[{"name":"staff"}]}}},` +
+		`{"operation":{"operation":"read","targetType":"space"},"subjects":{"user":{"results":[{"displayName":"Ada"}]}}},` +
+		`{"operation":{"operation":"read","targetType":"space"},"anonymousAccess":true},` +
+		`{"operation":{"operation":"administer","targetType":"space"},"subjects":{"user":{"results":[{"accountId":"557058:aaa"}]}}}]`
+	if err := json.Unmarshal([]byte(raw), &permissions); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	got := SummarizePermissions(permissions)
+	if len(got) != 2 || got[0].Permission != "administer space" || got[0].Users[0] != "557058:aaa" {
+		t.Fatalf("unexpected summary: %+v", got)
+	}
+	if read := got[1]; read.Permission != "read space" || !read.Anonymous || len(read.Users) != 1 || len(read.Groups) != 1 {
+		t.Fatalf("unexpected read summary: %+v", read)
+	}
+}
+
+func TestCreateSpaceWithHomepage(t *testing.T) {
+	t.Parallel()
+
+	var homepageBody string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		body := ""
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/rest/api/space/_private":
+			body = `{"key":"KICK","name":"Kickoff"}`
+		case req.Method == http.MethodGet && req.URL.Path == "/rest/api/space/KICK":
+			body = `{"key":"KICK","name":"Kickoff","homepage":{"id":"5"}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/rest/api/content/5":
+			body = `{"id":"5","type":"page","title":"Kickoff Home","version":{"number":1},"body":{"storage":{"value":""}}}`
+		case req.Method == http.MethodPut && req.URL.Path == "/rest/api/content/5":
+			var payload struct {
+				Body struct {
+					Storage struct {
+						Value string `json:"value"`
+					} `json:"storage"`
+				} `json:"body"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			homepageBody = payload.Body.Storage.Value
+			body = `{"id":"5","title":"Kickoff Home","version":{"number":2}}`
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	space, err := service.CreateSpace(context.Background(), SpaceInput{
+		Key:          "KICK",
+		Name:         "Kickoff",
+		HomepageBody: "<h1>Welcome</h1>",
+		Private:      true,
+	})
+	if err != nil {
+		t.Fatalf("CreateSpace error: %v", err)
+	}
+	if homepageBody != "<h1>Welcome</h1>" || space.Homepage.Version.Number != 2 {
+		t.Fatalf("homepage not updated: %q %+v", homepageBody, space.Homepage)
+	}
+}
+
+func TestSetProperty(t *testing.T) {
+	t.Parallel()
+
+	var writes []string
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		respond := func(status int, body string) (*http.Response, error) {
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+		}
+
+		switch {
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/property/generated-by"):
+			return respond(200, `{"key":"generated-by","value":"docs-bot","version":{"number":3}}`)
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/property/source-commit"):
+			return respond(http.StatusNotFound, "not found")
+		case req.Method == http.MethodPut || req.Method == http.MethodPost:
+			data, _ := io.ReadAll(req.Body)
+			writes = append(writes, req.Method+" "+req.URL.Path+" "+string(data))
+			return respond(200, `{"key":"x","version":{"number":1}}`)
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		return nil, nil
+	})
+
+	service := NewService(client)
+	if _, err := service.StampPage(context.Background(), "1", map[string]string{"source-commit": "abc123", "generated-by": "docs-bot"}); err != nil {
+		t.Fatalf("StampPage error: %v", err)
+	}
+
+	want := []string{
+		`PUT /rest/api/content/1/property/generated-by {"key":"generated-by","value":"docs-bot","version":{"minorEdit":true,"number":4}}`,
+		`POST /rest/api/content/1/property {"key":"source-commit","value":"abc123"}`,
+	}
+	if strings.Join(writes, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected writes:\n%s", strings.Join(writes, "\n"))
+	}
+
+	_, err := service.SetProperty(context.Background(), "1", "generated-by", "other", 2)
+	var conflict *PropertyConflictError
+	if !errors.As(err, &conflict) || conflict.Current != 3 {
+		t.Fatalf("expected property conflict, got %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/rest/api/search" {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		query := req.URL.Query()
+		if query.Get("excerpt") != "highlight" || query.Get("cursor") != "abc" {
+			t.Fatalf("unexpected query: %s", req.URL.RawQuery)
+		}
+		if got := query.Get("expand"); got != "content.space,content.body.view" {
+			t.Fatalf("unexpected expand: %s", got)
+		}
+
+		body := `{"results":[
+			{"content":{"id":"1","type":"page","title":"Runbook","space":{"key":"OPS","name":"Operations"}},
+			 "title":"@@@hl@@@Runbook@@@endhl@@@","excerpt":"Restart the @@@hl@@@service@@@endhl@@@ &amp;\n check logs",
+			 "resultGlobalContainer":{"title":"Operations","displayUrl":"/spaces/OPS"},"lastModified":"2026-01-02T03:04:05Z"},
+			{"title":"Legacy","resultGlobalContainer":{"title":"Archive","displayUrl":"/display/ARC"},"url":"/display/ARC/Legacy"}
+		],"start":0,"size":2,"totalSize":7,"_links":{"next":"/rest/api/search?cql=type%3Dpage&cursor=def&limit=2"}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})
+
+	service := NewService(client)
+	page, err := service.Search(context.Background(), SearchQuery{CQL: "type=page", Limit: 2, Cursor: "abc", Expand: []string{"space", "content.body.view"}})
+	if err != nil {
+		t.Fatalf("Search error: %v", err)
+	}
+
+	if len(page.Results) != 2 || page.TotalSize != 7 || !page.HasMore || page.NextCursor != "def" || page.NextStart != 2 {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+	first := page.Results[0]
+	if first.Title != "Runbook" || first.Excerpt != "Restart the **service** & check logs" || first.SpaceKey != "OPS" {
+		t.Fatalf("unexpected first result: %+v", first)
+	}
+	if page.Results[1].SpaceKey != "ARC" {
+		t.Fatalf("expected space key from container url, got %q", page.Results[1].SpaceKey)
+	}
+}
+
+func TestStorageToMarkdown(t *testing.T) {
+	t.Parallel()
+
+	storage := `<h2>Setup</h2><p>Run <code>make</code> then see <ac:link><ri:page ri:content-title="Other" /></ac:link> and snake_case *notes*.</p>` +
+		`<ul><li>one<ul><li>nested</li></ul></li><li><p>two</p></li></ul>` +
+		`<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter><ac:plain-text-body><![CDATA[if a < b {}]]></ac:plain-text-body></ac:structured-macro>` +
+		`<ac:structured-macro ac:name="warning"><ac:rich-text-body><p>Careful</p></ac:rich-text-body></ac:structured-macro>` +
+		`<table><tbody><tr><th>Key</th><th>Value</th></tr><tr><td>a|b</td><td><ac:image><ri:attachment ri:filename="d.png" /></ac:image></td></tr></tbody></table>` +
+		`<ac:task-list><ac:task><ac:task-status>complete</ac:task-status><ac:task-body>done</ac:task-body></ac:task></ac:task-list>`
+
+	got := StorageToMarkdown(storage, MarkdownLinks{
+		Page:       func(_, title string) string { return strings.ToLower(title) + ".md" },
+		Attachment: func(name string) string { return "files/" + name },
+	})
+
+	want := "## Setup\n\n" +
+		"Run `make` then see [Other](other.md) and snake_case \\*notes\\*.\n\n" +
+		"- one\n  - nested\n- two\n\n" +
+		"```go\nif a < b {}\n```\n\n" +
+		"> **Warning:** Careful\n\n" +
+		"| Key | Value |\n| --- | --- |\n| a\\|b | ![d.png](files/d.png) |\n\n" +
+		"- [x] done\n"
+	if got != want {
+		t.Fatalf("unexpected markdown:\n%s", got)
+	}
+}
+
+func TestMarkdownToStorage(t *testing.T) {
+	t.Parallel()
+
+	markdown := "## Setup\n\nRun `make` then see [Other](other.md#intro) and **bold** *it*.\n\n" +
+		"- one\n  - nested\n- two\n\n" +
+		"```go\nif a < b {}\n```\n\n" +
+		"> [!WARNING]\n> Careful\n\n" +
+		"| Key | Value |\n| --- | --- |\n| a | ![d](files/d.png) |\n\n" +
+		"- [x] done\n"
+
+	got := MarkdownToStorage(markdown, StorageLinks{
+		Page: func(href string) string {
+			if strings.HasPrefix(href, "other.md") {
+				return "Other"
+			}
+			return ""
+		},
+		Attachment: func(src string) string { return strings.TrimPrefix(src, "files/") },
+	})
+
+	want := "<h2>Setup</h2>\n" +
+		`<p>Run <code>make</code> then see <ac:link ac:anchor="intro"><ri:page ri:content-title="Other" /><ac:link-body>Other</ac:link-body></ac:link> and <strong>bold</strong> <em>it</em>.</p>` + "\n" +
+		"<ul><li>one<ul><li>nested</li></ul></li><li>two</li></ul>\n" +
+		`<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter><ac:plain-text-body><![CDATA[if a < b {}]]></ac:plain-text-body></ac:structured-macro>` + "\n" +
+		`<ac:structured-macro ac:name="warning"><ac:rich-text-body><p>Careful</p></ac:rich-text-body></ac:structured-macro>` + "\n" +
+		`<table><tbody><tr><th>Key</th><th>Value</th></tr><tr><td>a</td><td><ac:image ac:alt="d"><ri:attachment ri:filename="d.png" /></ac:image></td></tr></tbody></table>` + "\n" +
+		"<ac:task-list><ac:task><ac:task-status>complete</ac:task-status><ac:task-body>done</ac:task-body></ac:task></ac:task-list>"
+	if strings.TrimSpace(got) != want {
+		t.Fatalf("unexpected storage:\n%s", got)
+	}
+	if err := ValidateStorage(got); err != nil {
+		t.Fatalf("storage does not validate: %v", err)
+	}
+}
+
+func TestGetSpaceRootPagesEscapesSpaceKey(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.EscapedPath(); got != "/rest/api/space/~j%2Fdoe/content/page" {
+			t.Fatalf("unexpected path: %s", got)
+		}
+		if req.URL.Query().Get("depth") != "root" {
+			t.Fatalf("expected depth=root, got %s", req.URL.RawQuery)
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"results":[{"id":"1","title":"Home"}],"size":1,"_links":{}}`)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	pages, err := service.GetSpaceRootPages(context.Background(), "~j/doe")
+	if err != nil {
+		t.Fatalf("GetSpaceRootPages error: %v", err)
+	}
+	if len(pages) != 1 || pages[0].ID != "1" {
+		t.Fatalf("unexpected pages: %+v", pages)
+	}
+}
+
+func TestSearchByLabelSkipsBodies(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/rest/api/content/search") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+		query := req.URL.Query()
+		if query.Get("expand") != "version" {
+			t.Fatalf("expected only version to be expanded, got %q", query.Get("expand"))
+		}
+		if want := `space = "OPS" AND type = "page" AND label = "run-book" ORDER BY lastmodified DESC`; query.Get("cql") != want {
+			t.Fatalf("unexpected CQL: %s", query.Get("cql"))
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"results":[{"id":"1","title":"Runbook","version":{"number":2}}],"size":1,"_links":{}}`)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	pages, err := service.SearchByLabel(context.Background(), []string{"Run Book"}, "OPS", 0)
+	if err != nil {
+		t.Fatalf("SearchByLabel error: %v", err)
+	}
+	if len(pages) != 1 || pages[0].Version.Number != 2 {
+		t.Fatalf("unexpected pages: %+v", pages)
+	}
+}
+
 func TestAPIPath(t *testing.T) {
 	t.Parallel()
 
@@ -2,8 +2,14 @@ package confluence
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
 )
 
 // ListSpaces retrieves Confluence spaces.
@@ -31,3 +37,134 @@ func (s *Service) ListSpaces(ctx context.Context, limit int) ([]Space, error) {
 
 	return response.Results, nil
 }
+
+// GetSpace retrieves a space with its description, homepage, type, status
+// and, when the account may read them, its permissions.
+func (s *Service) GetSpace(ctx context.Context, key string) (*Space, error) {
+	if key == "" {
+		return nil, fmt.Errorf("confluence: space key required")
+	}
+
+	path := apiPath("space", url.PathEscape(key))
+	var space Space
+	err := s.client.Get(ctx, path+"?expand=description.plain,homepage,permissions", &space)
+	if atlassian.IsStatus(err, http.StatusForbidden) || atlassian.IsStatus(err, http.StatusBadRequest) {
+		// Permissions are only visible to space administrators.
+		space = Space{}
+		err = s.client.Get(ctx, path+"?expand=description.plain,homepage", &space)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &space, nil
+}
+
+// CreateSpace creates a space and, when HomepageBody is set, replaces the
+// content of the homepage Confluence creates with it.
+func (s *Service) CreateSpace(ctx context.Context, in SpaceInput) (*Space, error) {
+	if in.Key == "" {
+		return nil, fmt.Errorf("confluence: space key required")
+	}
+	if in.Name == "" {
+		return nil, fmt.Errorf("confluence: space name required")
+	}
+
+	payload := map[string]interface{}{
+		"key":  in.Key,
+		"name": in.Name,
+	}
+	if in.Description != "" {
+		payload["description"] = map[string]interface{}{
+			"plain": map[string]string{
+				"value":          in.Description,
+				"representation": "plain",
+			},
+		}
+	}
+
+	path := apiPath("space")
+	if in.Private {
+		path = apiPath("space", "_private")
+	}
+
+	var created Space
+	if err := s.client.Post(ctx, path, payload, &created); err != nil {
+		return nil, err
+	}
+
+	space, err := s.GetSpace(ctx, in.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.HomepageBody != "" {
+		if space.Homepage == nil || space.Homepage.ID == "" {
+			return nil, fmt.Errorf("confluence: space %s was created without a homepage", in.Key)
+		}
+		homepage, err := s.EditPage(ctx, space.Homepage.ID, PageEdit{Body: in.HomepageBody})
+		if err != nil {
+			return nil, fmt.Errorf("confluence: space %s created but updating its homepage failed: %w", in.Key, err)
+		}
+		space.Homepage = homepage
+	}
+
+	return space, nil
+}
+
+// ArchiveSpace archives a space, or restores an archived space to current
+// when archived is false.
+func (s *Service) ArchiveSpace(ctx context.Context, key string, archived bool) (*Space, error) {
+	if key == "" {
+		return nil, fmt.Errorf("confluence: space key required")
+	}
+
+	status := SpaceCurrent
+	if archived {
+		status = SpaceArchived
+	}
+
+	var updated Space
+	if err := s.client.Put(ctx, apiPath("space", url.PathEscape(key)), map[string]string{"status": status}, &updated); err != nil {
+		return nil, err
+	}
+	if updated.Status == "" {
+		updated.Status = status
+	}
+
+	return &updated, nil
+}
+
+// SummarizePermissions groups a space's permissions by operation, e.g.
+// "read space" or "administer space", sorted by permission name.
+func SummarizePermissions(permissions []SpacePermission) []PermissionSummary {
+	byName := map[string]*PermissionSummary{}
+	for _, permission := range permissions {
+		name := strings.TrimSpace(permission.Operation.Operation + " " + permission.Operation.TargetType)
+		summary, ok := byName[name]
+		if !ok {
+			summary = &PermissionSummary{Permission: name}
+			byName[name] = summary
+		}
+
+		for _, user := range permission.Subjects.User.Results {
+			label := user.DisplayName
+			if label == "" {
+				label = user.ID()
+			}
+			summary.Users = append(summary.Users, label)
+		}
+		for _, group := range permission.Subjects.Group.Results {
+			summary.Groups = append(summary.Groups, group.Name)
+		}
+		summary.Anonymous = summary.Anonymous || permission.AnonymousAccess
+	}
+
+	summaries := make([]PermissionSummary, 0, len(byName))
+	for _, summary := range byName {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Permission < summaries[j].Permission })
+
+	return summaries
+}
@@ -0,0 +1,299 @@
+package confluence
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// StorageToText flattens Confluence storage format (XHTML with ac:/ri:
+// macros) into plain text with one block per line. Headings are prefixed with
+// '#', list items with '-', and table cells are separated by " | ". The output
+// is meant for reading and diffing, not for round-tripping.
+func StorageToText(storage string) string {
+	decoder := newStorageDecoder(storage)
+
+	w := &textWriter{}
+	skip := 0
+	listDepth := 0
+	pre := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Best effort: keep whatever was converted before the malformed markup.
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := elementName(t.Name)
+			if skip > 0 {
+				skip++
+				continue
+			}
+
+			switch name {
+			case "ac:parameter", "style", "script":
+				skip = 1
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				w.newline()
+				w.write(strings.Repeat("#", int(name[1]-'0')) + " ")
+			case "ul", "ol":
+				w.newline()
+				listDepth++
+			case "li":
+				w.newline()
+				indent := listDepth - 1
+				if indent < 0 {
+					indent = 0
+				}
+				w.write(strings.Repeat("  ", indent) + "- ")
+			case "pre", "ac:plain-text-body":
+				w.newline()
+				pre++
+			case "br":
+				w.newline()
+			case "td", "th":
+				if !w.lineEmpty() {
+					w.write(" | ")
+				}
+			case "ac:image":
+				w.write("[image: " + imageName(decoder, &t) + "]")
+			case "ac:link":
+				w.writeInline(linkText(decoder))
+			default:
+				if blockElements[name] {
+					w.newline()
+				}
+			}
+
+		case xml.EndElement:
+			name := elementName(t.Name)
+			if skip > 0 {
+				skip--
+				continue
+			}
+
+			switch name {
+			case "ul", "ol":
+				listDepth--
+				w.newline()
+			case "pre", "ac:plain-text-body":
+				pre--
+				w.newline()
+			default:
+				if blockElements[name] || name == "li" || headingLevel(name) > 0 {
+					w.newline()
+				}
+			}
+
+		case xml.CharData:
+			if skip > 0 {
+				continue
+			}
+			if pre > 0 {
+				w.writePre(string(t))
+			} else {
+				w.writeInline(string(t))
+			}
+		}
+	}
+
+	return w.String()
+}
+
+// storageRoot wraps storage fragments so they parse as a single document.
+const storageRoot = "<root>"
+
+// newStorageDecoder returns a lenient decoder for a storage format fragment.
+// Offsets reported by the decoder include the len(storageRoot) prefix.
+func newStorageDecoder(storage string) *xml.Decoder {
+	decoder := xml.NewDecoder(strings.NewReader(storageRoot + storage + "</root>"))
+	decoder.Strict = false
+	decoder.AutoClose = voidElements
+	decoder.Entity = xml.HTMLEntity
+	return decoder
+}
+
+// ValidateStorage reports whether storage is well-formed XHTML, as required
+// by the Confluence storage format. HTML entities such as &nbsp; are allowed.
+func ValidateStorage(storage string) error {
+	decoder := xml.NewDecoder(strings.NewReader(storageRoot + storage + "</root>"))
+	decoder.Entity = xml.HTMLEntity
+
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// voidElements lists HTML elements that may appear unclosed. xml.HTMLAutoClose
+// cannot be used because it matches on local names and would treat ac:link as
+// an HTML <link>.
+var voidElements = []string{"br", "hr", "img", "col", "area", "input", "wbr"}
+
+var blockElements = map[string]bool{
+	"p": true, "div": true, "blockquote": true, "table": true, "tr": true,
+	"hr": true, "ac:structured-macro": true, "ac:rich-text-body": true,
+	"ac:task": true, "ac:layout-section": true, "ac:layout-cell": true,
+}
+
+func elementName(name xml.Name) string {
+	if name.Space != "" {
+		return strings.ToLower(name.Space + ":" + name.Local)
+	}
+	return strings.ToLower(name.Local)
+}
+
+func attr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if elementName(a.Name) == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// imageName consumes an ac:image element and returns the referenced file
+// name or URL.
+func imageName(decoder *xml.Decoder, start *xml.StartElement) string {
+	name := attr(*start, "ac:alt")
+	depth := 1
+	for depth > 0 {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch elementName(t.Name) {
+			case "ri:attachment":
+				name = attr(t, "ri:filename")
+			case "ri:url":
+				name = attr(t, "ri:value")
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+	if name == "" {
+		name = "embedded"
+	}
+	return name
+}
+
+// linkText consumes an ac:link element and returns its link body, falling
+// back to the title or name of the linked resource.
+func linkText(decoder *xml.Decoder) string {
+	var target string
+	var body strings.Builder
+	depth := 1
+	for depth > 0 {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch elementName(t.Name) {
+			case "ri:page", "ri:blog-post":
+				target = attr(t, "ri:content-title")
+			case "ri:attachment":
+				target = attr(t, "ri:filename")
+			case "ri:space":
+				target = attr(t, "ri:space-key")
+			case "ri:user":
+				target = "@user"
+			}
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			body.Write(t)
+		}
+	}
+	if text := strings.Join(strings.Fields(body.String()), " "); text != "" {
+		return text
+	}
+	return target
+}
+
+// textWriter accumulates lines, collapsing inline whitespace and blank lines.
+type textWriter struct {
+	lines []string
+	line  strings.Builder
+}
+
+func (w *textWriter) write(s string) {
+	w.line.WriteString(s)
+}
+
+func (w *textWriter) writeInline(s string) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		if s != "" && w.lineEndsWithText() {
+			w.line.WriteByte(' ')
+		}
+		return
+	}
+	if startsWithSpace(s) && w.lineEndsWithText() {
+		w.line.WriteByte(' ')
+	}
+	w.line.WriteString(strings.Join(fields, " "))
+	if endsWithSpace(s) {
+		w.line.WriteByte(' ')
+	}
+}
+
+func (w *textWriter) writePre(s string) {
+	parts := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, part := range parts {
+		if i > 0 {
+			w.flush(true)
+		}
+		w.line.WriteString(part)
+	}
+}
+
+func (w *textWriter) lineEmpty() bool {
+	return strings.TrimSpace(w.line.String()) == ""
+}
+
+func (w *textWriter) lineEndsWithText() bool {
+	current := w.line.String()
+	return current != "" && !endsWithSpace(current)
+}
+
+func (w *textWriter) newline() {
+	w.flush(false)
+}
+
+func (w *textWriter) flush(keepEmpty bool) {
+	line := strings.TrimRight(w.line.String(), " \t")
+	w.line.Reset()
+	if strings.TrimSpace(line) == "" && !keepEmpty {
+		return
+	}
+	w.lines = append(w.lines, line)
+}
+
+func (w *textWriter) String() string {
+	w.newline()
+	return strings.Join(w.lines, "\n")
+}
+
+func startsWithSpace(s string) bool {
+	return s != "" && strings.ContainsRune(" \t\r\n", rune(s[0]))
+}
+
+func endsWithSpace(s string) bool {
+	return s != "" && strings.ContainsRune(" \t\r\n", rune(s[len(s)-1]))
+}
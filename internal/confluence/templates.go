@@ -0,0 +1,267 @@
+package confluence
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ListTemplates lists the templates of a space, or the global templates when
+// spaceKey is empty. kind is TemplatePage or TemplateBlueprint.
+func (s *Service) ListTemplates(ctx context.Context, spaceKey, kind string) ([]Template, error) {
+	switch kind {
+	case "":
+		kind = TemplatePage
+	case TemplatePage, TemplateBlueprint:
+	default:
+		return nil, fmt.Errorf("confluence: invalid template kind %q (expected page or blueprint)", kind)
+	}
+
+	var templates []Template
+	for {
+		params := url.Values{}
+		if spaceKey != "" {
+			params.Set("spaceKey", spaceKey)
+		}
+		params.Set("start", strconv.Itoa(len(templates)))
+		params.Set("limit", strconv.Itoa(childPageSize))
+
+		var page struct {
+			Results []Template `json:"results"`
+			Links   struct {
+				Next string `json:"next"`
+			} `json:"_links"`
+		}
+		if err := s.client.Get(ctx, apiPath("template", kind)+"?"+params.Encode(), &page); err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, page.Results...)
+		if len(page.Results) == 0 || page.Links.Next == "" {
+			return templates, nil
+		}
+	}
+}
+
+// GetTemplate retrieves a template with its storage format body.
+func (s *Service) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: template id required")
+	}
+
+	params := url.Values{}
+	params.Set("expand", "body.storage")
+
+	var template Template
+	if err := s.client.Get(ctx, apiPath("template", id)+"?"+params.Encode(), &template); err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// CreateFromTemplate fills a template's variables and creates a page from
+// the result through CreatePage.
+func (s *Service) CreateFromTemplate(ctx context.Context, in TemplateInput) (*Content, error) {
+	template, err := s.GetTemplate(ctx, in.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.Body.Storage.Value == "" {
+		return nil, fmt.Errorf("confluence: template %s has no storage body", in.TemplateID)
+	}
+
+	body, err := FillTemplate(template.Body.Storage.Value, in.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, 0, len(template.Labels)+len(in.Labels))
+	for _, label := range template.Labels {
+		labels = append(labels, label.Name)
+	}
+	labels = append(labels, in.Labels...)
+
+	return s.CreatePage(ctx, PageInput{
+		SpaceKey:               in.SpaceKey,
+		Title:                  in.Title,
+		Body:                   body,
+		ParentID:               in.ParentID,
+		Labels:                 labels,
+		CopyParentRestrictions: in.CopyParentRestrictions,
+	})
+}
+
+// TemplateVariables returns the variables declared in a template body, in
+// declaration order, followed by any variables used but not declared.
+func TemplateVariables(storage string) ([]TemplateVariable, error) {
+	scan, err := scanTemplate(storage)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := scan.declared
+	seen := map[string]bool{}
+	for _, variable := range variables {
+		seen[variable.Name] = true
+	}
+	for _, use := range scan.uses {
+		if !seen[use.name] {
+			seen[use.name] = true
+			variables = append(variables, TemplateVariable{Name: use.name, Type: "string"})
+		}
+	}
+
+	return variables, nil
+}
+
+// FillTemplate replaces the at:var placeholders in a template body with the
+// given values and drops the variable declarations. Values are escaped unless
+// the placeholder is marked at:rawxhtml. Every variable used in the body must
+// have a value, and list variables only accept their declared options.
+func FillTemplate(storage string, values map[string]string) (string, error) {
+	scan, err := scanTemplate(storage)
+	if err != nil {
+		return "", err
+	}
+
+	var missing []string
+	for _, use := range scan.uses {
+		if _, ok := values[use.name]; !ok {
+			missing = append(missing, use.name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf("confluence: missing values for template variables: %s", strings.Join(dedupe(missing), ", "))
+	}
+
+	for _, variable := range scan.declared {
+		value, ok := values[variable.Name]
+		if !ok || len(variable.Options) == 0 {
+			continue
+		}
+		allowed := false
+		for _, option := range variable.Options {
+			allowed = allowed || option == value
+		}
+		if !allowed {
+			return "", fmt.Errorf("confluence: template variable %s must be one of: %s", variable.Name, strings.Join(variable.Options, ", "))
+		}
+	}
+
+	edits := append(append([]templateUse{}, scan.uses...), scan.removed...)
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var b strings.Builder
+	last := 0
+	for _, edit := range edits {
+		if edit.start < last {
+			continue
+		}
+		b.WriteString(storage[last:edit.start])
+		switch {
+		case edit.name == "":
+		case edit.raw:
+			b.WriteString(values[edit.name])
+		default:
+			b.WriteString(html.EscapeString(values[edit.name]))
+		}
+		last = edit.end
+	}
+	b.WriteString(storage[last:])
+
+	return b.String(), nil
+}
+
+// templateUse is an at:var placeholder with its byte range in the template
+// body.
+type templateUse struct {
+	name       string
+	raw        bool
+	start, end int
+}
+
+// templateScan is the result of scanning a template body. removed holds the
+// byte ranges of at:declarations blocks, which do not belong in pages.
+type templateScan struct {
+	declared []TemplateVariable
+	uses     []templateUse
+	removed  []templateUse
+}
+
+// scanTemplate records the declared variables and the byte ranges of
+// placeholders and declaration blocks in a template body.
+func scanTemplate(storage string) (*templateScan, error) {
+	decoder := newStorageDecoder(storage)
+	offset := len(storageRoot)
+
+	scan := &templateScan{}
+	declarations := -1
+	depth := 0
+
+	for {
+		start := int(decoder.InputOffset()) - offset
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("confluence: parse template: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			name := elementName(t.Name)
+			switch {
+			case name == "at:declarations" && declarations < 0:
+				declarations = depth
+				scan.removed = append(scan.removed, templateUse{start: start})
+			case declarations >= 0 && depth == declarations+1:
+				scan.declared = append(scan.declared, TemplateVariable{
+					Name: attr(t, "at:name"),
+					Type: strings.TrimPrefix(name, "at:"),
+				})
+			case declarations >= 0 && name == "at:option" && len(scan.declared) > 0:
+				last := &scan.declared[len(scan.declared)-1]
+				last.Options = append(last.Options, attr(t, "at:value"))
+			case name == "at:var" && declarations < 0:
+				scan.uses = append(scan.uses, templateUse{
+					name:  attr(t, "at:name"),
+					raw:   attr(t, "at:rawxhtml") == "true",
+					start: start,
+				})
+			}
+
+		case xml.EndElement:
+			end := int(decoder.InputOffset()) - offset
+			switch name := elementName(t.Name); {
+			case name == "at:declarations" && depth == declarations:
+				scan.removed[len(scan.removed)-1].end = end
+				declarations = -1
+			case name == "at:var" && declarations < 0 && len(scan.uses) > 0:
+				scan.uses[len(scan.uses)-1].end = end
+			}
+			depth--
+		}
+	}
+
+	return scan, nil
+}
+
+func dedupe(sorted []string) []string {
+	out := sorted[:0]
+	for i, value := range sorted {
+		if i == 0 || value != sorted[i-1] {
+			out = append(out, value)
+		}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	childPageSize          = 50
+	defaultDescendantDepth = 3
+	maxDescendantDepth     = 10
+	defaultDescendantLimit = 200
+	maxDescendantLimit     = 1000
+)
+
+// GetChildren retrieves all direct child pages of a page, following pagination.
+func (s *Service) GetChildren(ctx context.Context, id string) ([]Content, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+
+	return s.collectPages(ctx, apiPath("content", id, "child", "page"), url.Values{"expand": {"version"}}, 0)
+}
+
+// GetSpaceRootPages retrieves the top-level pages of a space.
+func (s *Service) GetSpaceRootPages(ctx context.Context, spaceKey string) ([]Content, error) {
+	if spaceKey == "" {
+		return nil, fmt.Errorf("confluence: space key required")
+	}
+
+	params := url.Values{}
+	params.Set("depth", "root")
+	params.Set("expand", "version")
+
+	return s.collectPages(ctx, apiPath("space", url.PathEscape(spaceKey), "content", "page"), params, 0)
+}
+
+// GetAncestors returns the ancestors of a page ordered from the space root down
+// to the direct parent.
+func (s *Service) GetAncestors(ctx context.Context, id string) ([]Content, error) {
+	page, err := s.GetPage(ctx, id, []string{"ancestors"})
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Ancestors, nil
+}
+
+// GetDescendants walks the page tree below id breadth-first and returns the
+// pages in depth-first order, each annotated with its parent and depth (1 for
+// direct children). The walk stops at MaxDepth levels or Limit pages; the
+// boolean result reports whether pages were dropped to honour Limit.
+func (s *Service) GetDescendants(ctx context.Context, id string, opts DescendantOptions) ([]PageNode, bool, error) {
+	if id == "" {
+		return nil, false, fmt.Errorf("confluence: page id required")
+	}
+
+	maxDepth := opts.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultDescendantDepth
+	}
+	if maxDepth > maxDescendantDepth {
+		maxDepth = maxDescendantDepth
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultDescendantLimit
+	}
+	if limit > maxDescendantLimit {
+		limit = maxDescendantLimit
+	}
+
+	children := map[string][]Content{}
+	level := []string{id}
+	count := 0
+	truncated := false
+
+	for depth := 1; depth <= maxDepth && len(level) > 0 && !truncated; depth++ {
+		var next []string
+		for _, parent := range level {
+			kids, err := s.GetChildren(ctx, parent)
+			if err != nil {
+				return nil, false, err
+			}
+			if count+len(kids) > limit {
+				kids = kids[:limit-count]
+				truncated = true
+			}
+			children[parent] = kids
+			count += len(kids)
+			for _, kid := range kids {
+				next = append(next, kid.ID)
+			}
+			if truncated {
+				break
+			}
+		}
+		level = next
+	}
+
+	nodes := make([]PageNode, 0, count)
+	var walk func(parent string, depth int)
+	walk = func(parent string, depth int) {
+		for _, kid := range children[parent] {
+			nodes = append(nodes, PageNode{Content: kid, ParentID: parent, Depth: depth})
+			walk(kid.ID, depth+1)
+		}
+	}
+	walk(id, 1)
+
+	return nodes, truncated, nil
+}
+
+// collectPages follows start/limit pagination for content listings, switching
+// to the cursor from _links.next when the server provides one, as Cloud does
+// for CQL searches. A positive maxResults stops collection once that many
+// results have been gathered.
+func (s *Service) collectPages(ctx context.Context, path string, params url.Values, maxResults int) ([]Content, error) {
+	var results []Content
+	cursor := ""
+
+	for {
+		if cursor != "" {
+			params.Del("start")
+			params.Set("cursor", cursor)
+		} else {
+			params.Set("start", strconv.Itoa(len(results)))
+		}
+		params.Set("limit", strconv.Itoa(childPageSize))
+
+		var page struct {
+			Results []Content `json:"results"`
+			Size    int       `json:"size"`
+			Links   struct {
+				Next string `json:"next"`
+			} `json:"_links"`
+		}
+		if err := s.client.Get(ctx, path+"?"+params.Encode(), &page); err != nil {
+			return nil, err
+		}
+
+		results = append(results, page.Results...)
+
+		if page.Links.Next == "" || len(page.Results) == 0 || (maxResults > 0 && len(results) >= maxResults) {
+			break
+		}
+		if next, err := url.Parse(page.Links.Next); err == nil {
+			cursor = next.Query().Get("cursor")
+		}
+	}
+
+	if maxResults > 0 && len(results) > maxResults {
+		results = results[:maxResults]
+	}
+
+	return results, nil
+}
@@ -15,6 +15,56 @@ type Space struct {
 			Value string `json:"value"`
 		} `json:"plain"`
 	} `json:"description"`
+	Homepage    *Content          `json:"homepage,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	Permissions []SpacePermission `json:"permissions,omitempty"`
+}
+
+// Space types and statuses.
+const (
+	SpaceGlobal   = "global"
+	SpacePersonal = "personal"
+
+	SpaceCurrent  = "current"
+	SpaceArchived = "archived"
+)
+
+// SpacePermission grants an operation on a space to users and groups.
+type SpacePermission struct {
+	Operation struct {
+		Operation  string `json:"operation"`
+		TargetType string `json:"targetType"`
+	} `json:"operation"`
+	Subjects struct {
+		User struct {
+			Results []User `json:"results"`
+		} `json:"user"`
+		Group struct {
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		} `json:"group"`
+	} `json:"subjects"`
+	AnonymousAccess bool `json:"anonymousAccess"`
+}
+
+// SpaceInput describes a new space. HomepageBody, when set, replaces the
+// default homepage content; Private spaces are visible only to their creator.
+type SpaceInput struct {
+	Key          string
+	Name         string
+	Description  string
+	HomepageBody string
+	Private      bool
+}
+
+// PermissionSummary lists who holds one space permission, e.g. "read space".
+type PermissionSummary struct {
+	Permission string
+	Users      []string
+	Groups     []string
+	Anonymous  bool
 }
 
 // Content represents Confluence content (pages, blog posts).
@@ -32,13 +82,373 @@ type Content struct {
 			Representation string `json:"representation"`
 		} `json:"storage"`
 	} `json:"body"`
+	Ancestors []Content        `json:"ancestors,omitempty"`
+	Space     *Space           `json:"space,omitempty"`
+	Metadata  *ContentMetadata `json:"metadata,omitempty"`
+	History   *ContentHistory  `json:"history,omitempty"`
+}
+
+// Content types accepted by PageInput.Type.
+const (
+	TypePage     = "page"
+	TypeBlogPost = "blogpost"
+)
+
+// ContentHistory holds expanded content creation details.
+type ContentHistory struct {
+	CreatedDate string `json:"createdDate"`
+	CreatedBy   struct {
+		DisplayName string `json:"displayName"`
+		Username    string `json:"username"`
+	} `json:"createdBy"`
+	LastUpdated *ContentUpdate `json:"lastUpdated,omitempty"`
+}
+
+// ContentUpdate describes the latest version of a piece of content.
+type ContentUpdate struct {
+	When string `json:"when"`
+	By   struct {
+		DisplayName string `json:"displayName"`
+		Username    string `json:"username"`
+	} `json:"by"`
+}
+
+// SearchQuery configures a search through the /search endpoint.
+type SearchQuery struct {
+	CQL    string
+	Limit  int
+	Start  int
+	Cursor string
+	// Expand lists content expansions; an empty list selects space,
+	// version, history.lastUpdated and ancestors.
+	Expand []string
+}
+
+// SearchResult is a single hit returned by the /search endpoint.
+type SearchResult struct {
+	Content      *Content `json:"content,omitempty"`
+	Title        string   `json:"title"`
+	Excerpt      string   `json:"excerpt"`
+	URL          string   `json:"url"`
+	EntityType   string   `json:"entityType"`
+	LastModified string   `json:"lastModified"`
+	Container    struct {
+		Title      string `json:"title"`
+		DisplayURL string `json:"displayUrl"`
+	} `json:"resultGlobalContainer"`
+	Breadcrumbs []struct {
+		Label string `json:"label"`
+		URL   string `json:"url"`
+	} `json:"breadcrumbs"`
+	// SpaceKey is taken from the expanded content space, or from the
+	// container URL when the space was not expanded.
+	SpaceKey string `json:"-"`
+}
+
+// SearchPage is one page of search results. NextCursor (Cloud) or
+// NextStart (Data Center) fetches the following page when HasMore is set.
+type SearchPage struct {
+	Results    []SearchResult
+	Start      int
+	TotalSize  int
+	HasMore    bool
+	NextStart  int
+	NextCursor string
 }
 
-// PageInput describes a page create/update request.
+// ContentMetadata holds expanded content metadata.
+type ContentMetadata struct {
+	Labels struct {
+		Results []Label `json:"results"`
+	} `json:"labels"`
+}
+
+// Label is a content label.
+type Label struct {
+	ID     string `json:"id,omitempty"`
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+// LabelCount is a label with the number of pages carrying it.
+type LabelCount struct {
+	Name  string
+	Count int
+}
+
+// PageInput describes a page create/update request. Type defaults to
+// TypePage; blog posts cannot have a parent. CopyParentRestrictions applies
+// the parent's view and edit restrictions to a newly created page.
 type PageInput struct {
-	SpaceKey string
-	Title    string
-	Body     string
+	Type                   string
+	SpaceKey               string
+	Title                  string
+	Body                   string
+	ParentID               string
+	Version                int
+	Labels                 []string
+	CopyParentRestrictions bool
+}
+
+// PageNode is a page positioned within a page tree.
+type PageNode struct {
+	Content
 	ParentID string
-	Version  int
+	Depth    int
+}
+
+// DescendantOptions bounds a descendant walk. Zero values use the defaults.
+type DescendantOptions struct {
+	MaxDepth int
+	Limit    int
+}
+
+// Move positions accepted by MovePage.
+const (
+	MoveBefore = "before"
+	MoveAfter  = "after"
+	MoveAppend = "append"
+)
+
+// MoveInput describes where to move a page. Position is relative to TargetID:
+// before/after make the page a sibling of the target, append makes it the
+// target's last child. Set SpaceKey instead of TargetID to append the page to
+// the homepage of that space.
+type MoveInput struct {
+	TargetID string
+	SpaceKey string
+	Position string
+}
+
+// PageVersion describes one entry in a page's version history.
+type PageVersion struct {
+	Number    int    `json:"number"`
+	When      string `json:"when"`
+	Message   string `json:"message"`
+	MinorEdit bool   `json:"minorEdit"`
+	By        struct {
+		DisplayName string `json:"displayName"`
+		AccountID   string `json:"accountId"`
+		Username    string `json:"username"`
+	} `json:"by"`
+}
+
+// PageEdit describes a version-aware page update. The current version is read
+// before writing; Type, Title and Body default to the current values when
+// empty.
+// Apply, when set, derives the new body from the current one and is re-run if
+// the write has to be retried. A positive ExpectedVersion turns concurrent
+// edits into a ConflictError instead of a retry.
+type PageEdit struct {
+	Type            string
+	Title           string
+	Body            string
+	Apply           func(current string) (string, error)
+	SpaceKey        string
+	ParentID        string
+	ExpectedVersion int
+}
+
+// Comment locations.
+const (
+	CommentFooter = "footer"
+	CommentInline = "inline"
+)
+
+// Comment is a footer or inline comment on a page. Replies carry their parent
+// comments in Ancestors.
+type Comment struct {
+	Content
+	Extensions struct {
+		Location         string `json:"location"`
+		InlineProperties *struct {
+			OriginalSelection string `json:"originalSelection"`
+			MarkerRef         string `json:"markerRef"`
+		} `json:"inlineProperties,omitempty"`
+		Resolution *struct {
+			Status string `json:"status"`
+		} `json:"resolution,omitempty"`
+	} `json:"extensions"`
+}
+
+// ParentID returns the ID of the comment this one replies to, or "" for a
+// top-level comment.
+func (c Comment) ParentID() string {
+	for i := len(c.Ancestors) - 1; i >= 0; i-- {
+		if c.Ancestors[i].Type == "comment" {
+			return c.Ancestors[i].ID
+		}
+	}
+	return ""
+}
+
+// Attachment is a file attached to a page.
+type Attachment struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Metadata struct {
+		MediaType string `json:"mediaType"`
+		Comment   string `json:"comment"`
+	} `json:"metadata"`
+	Extensions struct {
+		MediaType string `json:"mediaType"`
+		FileSize  int64  `json:"fileSize"`
+		Comment   string `json:"comment"`
+	} `json:"extensions"`
+	Links struct {
+		Download string `json:"download"`
+	} `json:"_links"`
+}
+
+// MediaType returns the attachment's MIME type.
+func (a Attachment) MediaType() string {
+	if a.Metadata.MediaType != "" {
+		return a.Metadata.MediaType
+	}
+	return a.Extensions.MediaType
+}
+
+// AttachmentInput describes an attachment upload.
+type AttachmentInput struct {
+	FileName  string
+	Data      []byte
+	Comment   string
+	MinorEdit bool
+}
+
+// ImageOptions controls the ac:image markup produced by ImageMarkup.
+type ImageOptions struct {
+	Width int
+	Alt   string
+	// PageTitle references an attachment on another page in the same space.
+	PageTitle string
+}
+
+// Template kinds accepted by ListTemplates.
+const (
+	TemplatePage      = "page"
+	TemplateBlueprint = "blueprint"
+)
+
+// Template is a page template or blueprint. Space is nil for global
+// templates.
+type Template struct {
+	TemplateID   string  `json:"templateId"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	TemplateType string  `json:"templateType"`
+	Labels       []Label `json:"labels"`
+	Space        *Space  `json:"space,omitempty"`
+	Body         struct {
+		Storage struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+}
+
+// TemplateVariable is a variable declared by a template. Options lists the
+// allowed values of list variables.
+type TemplateVariable struct {
+	Name    string
+	Type    string
+	Options []string
+}
+
+// TemplateInput describes a page created from a template. Variables maps
+// declared variable names to their values; Labels are applied in addition to
+// the template's own labels.
+type TemplateInput struct {
+	TemplateID             string
+	SpaceKey               string
+	Title                  string
+	ParentID               string
+	Variables              map[string]string
+	Labels                 []string
+	CopyParentRestrictions bool
+}
+
+// Restriction operations. Confluence calls view restrictions "read" and edit
+// restrictions "update".
+const (
+	RestrictionView = "read"
+	RestrictionEdit = "update"
+)
+
+// User is a Confluence user. Cloud identifies users by AccountID, Data Center
+// by Username.
+type User struct {
+	AccountID   string `json:"accountId,omitempty"`
+	Username    string `json:"username,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// ID returns the identifier the current deployment uses for the user.
+func (u User) ID() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Username
+}
+
+// Restriction lists the users and groups an operation is restricted to. An
+// operation without users or groups is unrestricted.
+type Restriction struct {
+	Operation string
+	Users     []User
+	Groups    []string
+}
+
+// RestrictionChange adds and removes users and groups on one operation.
+// Users are account IDs on Cloud and usernames on Data Center.
+type RestrictionChange struct {
+	Operation    string
+	AddUsers     []string
+	RemoveUsers  []string
+	AddGroups    []string
+	RemoveGroups []string
+}
+
+// PermissionCheck is the outcome of CheckPermission. Reason explains denials
+// and how the answer was reached.
+type PermissionCheck struct {
+	Allowed bool
+	Reason  string
+}
+
+// CopyOptions controls CopyPage. Copies are created under TargetParentID, or
+// under the homepage of SpaceKey when no parent is given. Titles become
+// TitlePrefix + title + TitleSuffix. Limit bounds the number of descendants
+// copied; Progress, when set, is called after each page is copied.
+type CopyOptions struct {
+	TargetParentID     string
+	SpaceKey           string
+	TitlePrefix        string
+	TitleSuffix        string
+	IncludeDescendants bool
+	CopyLabels         bool
+	CopyAttachments    bool
+	Limit              int
+	Progress           func(done, total int, page CopiedPage)
+}
+
+// CopiedPage maps a source page to its copy.
+type CopiedPage struct {
+	SourceID    string
+	ID          string
+	Title       string
+	ParentID    string
+	Attachments int
+}
+
+// ContentProperty is a JSON value stored on a piece of content under a key.
+type ContentProperty struct {
+	ID      string          `json:"id,omitempty"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
 }
@@ -0,0 +1,151 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+)
+
+// ListVersions returns a page's version history, newest first. A positive
+// limit caps the number of versions returned.
+func (s *Service) ListVersions(ctx context.Context, id string, limit int) ([]PageVersion, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+
+	var versions []PageVersion
+	for {
+		params := url.Values{}
+		params.Set("start", strconv.Itoa(len(versions)))
+		params.Set("limit", strconv.Itoa(childPageSize))
+
+		var page struct {
+			Results []PageVersion `json:"results"`
+			Links   struct {
+				Next string `json:"next"`
+			} `json:"_links"`
+		}
+		if err := s.client.Get(ctx, apiPath("content", id, "version")+"?"+params.Encode(), &page); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, page.Results...)
+		if len(page.Results) == 0 || page.Links.Next == "" || (limit > 0 && len(versions) >= limit) {
+			break
+		}
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Number > versions[j].Number
+	})
+	if limit > 0 && len(versions) > limit {
+		versions = versions[:limit]
+	}
+
+	return versions, nil
+}
+
+// GetPageAtVersion retrieves a page as it was at the given version, including
+// its storage body.
+func (s *Service) GetPageAtVersion(ctx context.Context, id string, version int) (*Content, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+	if version <= 0 {
+		return nil, fmt.Errorf("confluence: version must be positive")
+	}
+
+	params := url.Values{}
+	params.Set("status", "historical")
+	params.Set("version", strconv.Itoa(version))
+	params.Set("expand", "body.storage,version")
+
+	var page Content
+	err := s.client.Get(ctx, apiPath("content", id)+"?"+params.Encode(), &page)
+	if err == nil {
+		return &page, nil
+	}
+	if !atlassian.IsStatus(err, http.StatusNotFound) && !atlassian.IsStatus(err, http.StatusBadRequest) {
+		return nil, err
+	}
+
+	// Some deployments only serve the latest version without status=current.
+	current, currentErr := s.GetPage(ctx, id, []string{"body.storage", "version"})
+	if currentErr != nil {
+		return nil, err
+	}
+	if current.Version.Number != version {
+		return nil, fmt.Errorf("confluence: version %d of page %s not found: %w", version, id, err)
+	}
+
+	return current, nil
+}
+
+// VersionAt returns the newest version created at or before t, or nil when
+// the page did not exist yet.
+func VersionAt(versions []PageVersion, t time.Time) *PageVersion {
+	var best *PageVersion
+	for i := range versions {
+		when, err := time.Parse(time.RFC3339, versions[i].When)
+		if err != nil || when.After(t) {
+			continue
+		}
+		if best == nil || versions[i].Number > best.Number {
+			best = &versions[i]
+		}
+	}
+	return best
+}
+
+// RestoreVersion makes the content of an earlier version the new current
+// version. It uses the version restore operation where available and falls
+// back to republishing the old title and body.
+func (s *Service) RestoreVersion(ctx context.Context, id string, version int, message string) (*Content, error) {
+	if id == "" {
+		return nil, fmt.Errorf("confluence: page id required")
+	}
+	if version <= 0 {
+		return nil, fmt.Errorf("confluence: version must be positive")
+	}
+	if message == "" {
+		message = fmt.Sprintf("Restored version %d", version)
+	}
+
+	payload := map[string]interface{}{
+		"operationKey": "restore",
+		"params": map[string]interface{}{
+			"versionNumber": version,
+			"message":       message,
+		},
+	}
+
+	err := s.client.Post(ctx, apiPath("content", id, "version"), payload, nil)
+	if err == nil {
+		return s.GetPage(ctx, id, []string{"version"})
+	}
+	if !atlassian.IsStatus(err, http.StatusNotFound) && !atlassian.IsStatus(err, http.StatusMethodNotAllowed) {
+		return nil, err
+	}
+
+	old, err := s.GetPageAtVersion(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.GetPage(ctx, id, []string{"version"})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.UpdatePage(ctx, id, PageInput{
+		Type:    current.Type,
+		Title:   old.Title,
+		Body:    old.Body.Storage.Value,
+		Version: current.Version.Number + 1,
+	})
+}
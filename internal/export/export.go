@@ -0,0 +1,525 @@
+// Package export mirrors Confluence pages into a local directory of
+// Markdown files.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+)
+
+const (
+	// ManifestFile records what was exported so later runs only rewrite
+	// pages whose version changed.
+	ManifestFile = ".confluence-export.json"
+	// AttachmentsDir holds downloaded attachments, one directory per page.
+	AttachmentsDir = "_attachments"
+
+	defaultLimit       = 1000
+	attachmentMaxBytes = 100 << 20
+)
+
+// Page statuses reported by Export.
+const (
+	StatusWritten   = "written"
+	StatusUnchanged = "unchanged"
+)
+
+// Options selects what to export and where.
+type Options struct {
+	SpaceKey string
+	RootID   string
+	Dir      string
+	// Attachments downloads page attachments next to the Markdown files.
+	Attachments bool
+	// Limit caps the number of pages; the export fails if there are more.
+	Limit int
+	// BaseURL is the Confluence UI base used for source links.
+	BaseURL  string
+	Progress func(done, total int, page Page)
+}
+
+// Page reports the outcome for one exported page.
+type Page struct {
+	ID          string
+	Title       string
+	Path        string
+	Version     int
+	Status      string
+	Attachments int
+}
+
+// Report summarises an export.
+type Report struct {
+	Dir       string
+	Pages     []Page
+	Written   int
+	Unchanged int
+	Removed   []string
+}
+
+// Exporter writes Confluence page trees to disk.
+type Exporter struct {
+	service *confluence.Service
+}
+
+// NewExporter constructs an Exporter.
+func NewExporter(service *confluence.Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+type manifest struct {
+	SpaceKey string                   `json:"spaceKey,omitempty"`
+	RootID   string                   `json:"rootId,omitempty"`
+	Pages    map[string]manifestEntry `json:"pages"`
+}
+
+type manifestEntry struct {
+	Path        string         `json:"path"`
+	Version     int            `json:"version"`
+	Attachments map[string]int `json:"attachments,omitempty"`
+}
+
+type node struct {
+	content  confluence.Content
+	parentID string
+	path     string
+}
+
+// Export walks a space or a page subtree and writes one Markdown file per
+// page, children in a directory named after their parent. Pages whose
+// version and location match the manifest of a previous run are left alone;
+// when any page moved, every page is rewritten so relative links stay valid.
+// Files of pages that no longer exist are removed. A directory holding the
+// export of another space or page tree is refused.
+func (e *Exporter) Export(ctx context.Context, opts Options) (*Report, error) {
+	if strings.TrimSpace(opts.Dir) == "" {
+		return nil, fmt.Errorf("export: output directory required")
+	}
+	if (opts.SpaceKey == "") == (opts.RootID == "") {
+		return nil, fmt.Errorf("export: exactly one of space key or root page id required")
+	}
+
+	previous, err := loadManifest(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(previous.Pages) > 0 {
+		if previous.RootID != opts.RootID || (opts.RootID == "" && !strings.EqualFold(previous.SpaceKey, opts.SpaceKey)) {
+			return nil, fmt.Errorf("export: %s holds an export of %s; use another directory to export %s",
+				opts.Dir, describeSource(previous.SpaceKey, previous.RootID), describeSource(opts.SpaceKey, opts.RootID))
+		}
+	}
+
+	nodes, spaceKey, err := e.collect(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	assignPaths(nodes)
+
+	current := manifest{SpaceKey: spaceKey, RootID: opts.RootID, Pages: map[string]manifestEntry{}}
+	byTitle := map[string]*node{}
+	byID := map[string]*node{}
+	rewriteAll := false
+	for _, n := range nodes {
+		byTitle[n.content.Title] = n
+		byID[n.content.ID] = n
+		if old, ok := previous.Pages[n.content.ID]; ok && old.Path != n.path {
+			rewriteAll = true
+		}
+	}
+	for id := range previous.Pages {
+		if byID[id] == nil {
+			rewriteAll = true
+		}
+	}
+
+	report := &Report{Dir: opts.Dir}
+	saveErr := func(err error) error {
+		for id, entry := range previous.Pages {
+			if _, ok := current.Pages[id]; !ok && byID[id] != nil {
+				current.Pages[id] = entry
+			}
+		}
+		if saveErr := saveManifest(opts.Dir, current); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		return err
+	}
+
+	for i, n := range nodes {
+		old, known := previous.Pages[n.content.ID]
+		entry := manifestEntry{Path: n.path, Version: n.content.Version.Number}
+		page := Page{ID: n.content.ID, Title: n.content.Title, Path: n.path, Version: entry.Version, Status: StatusUnchanged}
+
+		if rewriteAll || !known || old.Version != entry.Version || !exists(filepath.Join(opts.Dir, filepath.FromSlash(n.path))) {
+			if err := e.writePage(ctx, opts, spaceKey, n, byTitle, byID); err != nil {
+				return nil, saveErr(err)
+			}
+			page.Status = StatusWritten
+			report.Written++
+		} else {
+			report.Unchanged++
+		}
+
+		if opts.Attachments {
+			attachments, err := e.syncAttachments(ctx, opts.Dir, n.content.ID, old.Attachments)
+			if err != nil {
+				return nil, saveErr(err)
+			}
+			entry.Attachments = attachments
+			page.Attachments = len(attachments)
+		}
+
+		current.Pages[n.content.ID] = entry
+		report.Pages = append(report.Pages, page)
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(nodes), page)
+		}
+	}
+
+	for id, old := range previous.Pages {
+		if byID[id] != nil || !exportedPath(old.Path) {
+			continue
+		}
+		removeFile(opts.Dir, old.Path)
+		if err := removeAttachments(opts.Dir, id); err != nil {
+			return nil, saveErr(err)
+		}
+		report.Removed = append(report.Removed, old.Path)
+	}
+	sort.Strings(report.Removed)
+
+	if err := saveManifest(opts.Dir, current); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// collect lists the pages to export, parents before children.
+func (e *Exporter) collect(ctx context.Context, opts Options) ([]*node, string, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	var roots []confluence.Content
+	spaceKey := opts.SpaceKey
+	if opts.RootID != "" {
+		root, err := e.service.GetPage(ctx, opts.RootID, []string{"version", "space"})
+		if err != nil {
+			return nil, "", err
+		}
+		if root.Space != nil {
+			spaceKey = root.Space.Key
+		}
+		roots = []confluence.Content{*root}
+	} else {
+		pages, err := e.service.GetSpaceRootPages(ctx, spaceKey)
+		if err != nil {
+			return nil, "", err
+		}
+		roots = pages
+	}
+
+	var nodes []*node
+	var walk func(page confluence.Content, parentID string) error
+	walk = func(page confluence.Content, parentID string) error {
+		if len(nodes) >= limit {
+			return fmt.Errorf("export: more than %d pages; raise the limit to export the whole tree", limit)
+		}
+		nodes = append(nodes, &node{content: page, parentID: parentID})
+
+		children, err := e.service.GetChildren(ctx, page.ID)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err := walk(child, page.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, root := range roots {
+		if err := walk(root, ""); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return nodes, spaceKey, nil
+}
+
+// assignPaths gives every page a slash separated path relative to the
+// export directory. Siblings with the same slug are told apart by page ID.
+func assignPaths(nodes []*node) {
+	dirs := map[string]string{"": ""}
+	used := map[string]bool{}
+
+	for _, n := range nodes {
+		dir := dirs[n.parentID]
+		name := slug(n.content.Title)
+		if name == "" {
+			name = n.content.ID
+		}
+		candidate := path.Join(dir, name)
+		if used[strings.ToLower(candidate)] {
+			candidate = path.Join(dir, name+"-"+n.content.ID)
+		}
+		used[strings.ToLower(candidate)] = true
+
+		n.path = candidate + ".md"
+		dirs[n.content.ID] = candidate
+	}
+}
+
+func (e *Exporter) writePage(ctx context.Context, opts Options, spaceKey string, n *node, byTitle, byID map[string]*node) error {
+	page, err := e.service.GetPage(ctx, n.content.ID, []string{"body.storage", "version", "metadata.labels"})
+	if err != nil {
+		return err
+	}
+
+	dir := path.Dir(n.path)
+	relative := func(target string) string {
+		rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(target))
+		if err != nil {
+			return ""
+		}
+		return filepath.ToSlash(rel)
+	}
+
+	links := confluence.MarkdownLinks{
+		Page: func(key, title string) string {
+			if key != "" && !strings.EqualFold(key, spaceKey) {
+				return ""
+			}
+			if target := byTitle[title]; target != nil {
+				return relative(target.path)
+			}
+			return ""
+		},
+		URL: func(href string) string {
+			if match := pageIDPattern.FindStringSubmatch(href); match != nil {
+				if target := byID[match[1]+match[2]]; target != nil {
+					return relative(target.path)
+				}
+			}
+			return href
+		},
+	}
+	if opts.Attachments {
+		links.Attachment = func(fileName string) string {
+			return relative(path.Join(AttachmentsDir, n.content.ID, attachmentFileName(fileName)))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %s\n", strconv.Quote(page.Title))
+	fmt.Fprintf(&b, "id: %s\n", strconv.Quote(page.ID))
+	fmt.Fprintf(&b, "version: %d\n", page.Version.Number)
+	if spaceKey != "" {
+		fmt.Fprintf(&b, "space: %s\n", strconv.Quote(spaceKey))
+	}
+	if n.parentID != "" {
+		fmt.Fprintf(&b, "parent: %s\n", strconv.Quote(n.parentID))
+	}
+	if page.Metadata != nil && len(page.Metadata.Labels.Results) > 0 {
+		labels := make([]string, 0, len(page.Metadata.Labels.Results))
+		for _, label := range page.Metadata.Labels.Results {
+			labels = append(labels, strconv.Quote(label.Name))
+		}
+		sort.Strings(labels)
+		fmt.Fprintf(&b, "labels: [%s]\n", strings.Join(labels, ", "))
+	}
+	if opts.BaseURL != "" {
+		fmt.Fprintf(&b, "source: %s\n", strconv.Quote(strings.TrimRight(opts.BaseURL, "/")+"/pages/"+page.ID))
+	}
+	b.WriteString("---\n\n")
+	fmt.Fprintf(&b, "# %s\n\n", page.Title)
+	b.WriteString(confluence.StorageToMarkdown(page.Body.Storage.Value, links))
+
+	return writeFile(opts.Dir, n.path, []byte(b.String()))
+}
+
+// pageIDPattern matches Confluence page URLs in Cloud and Data Center form.
+var pageIDPattern = regexp.MustCompile(`/pages/(\d+)|[?&]pageId=(\d+)`)
+
+// syncAttachments downloads new or updated attachments of a page and removes
+// files of deleted ones. It returns file name to version for the manifest.
+func (e *Exporter) syncAttachments(ctx context.Context, dir, pageID string, previous map[string]int) (map[string]int, error) {
+	attachments, err := e.service.ListAttachments(ctx, pageID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[string]int{}
+	for _, attachment := range attachments {
+		name := attachmentFileName(attachment.Title)
+		rel := path.Join(AttachmentsDir, pageID, name)
+		current[name] = attachment.Version.Number
+
+		if previous[name] == attachment.Version.Number && exists(filepath.Join(dir, filepath.FromSlash(rel))) {
+			continue
+		}
+		data, err := e.service.DownloadAttachment(ctx, attachment, attachmentMaxBytes)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeFile(dir, rel, data); err != nil {
+			return nil, err
+		}
+	}
+
+	for name := range previous {
+		if _, ok := current[name]; !ok && name == attachmentFileName(name) {
+			removeFile(dir, path.Join(AttachmentsDir, pageID, name))
+		}
+	}
+	if len(current) == 0 {
+		return nil, nil
+	}
+	return current, nil
+}
+
+// slug turns a title into a file name: lower case letters and digits
+// separated by single hyphens.
+func slug(title string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			hyphen = false
+		} else if !hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// attachmentFileName strips directory components from an attachment title.
+func attachmentFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "." || name == ".." || name == "/" {
+		return "attachment"
+	}
+	return name
+}
+
+func loadManifest(dir string) (manifest, error) {
+	m := manifest{Pages: map[string]manifestEntry{}}
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return m, fmt.Errorf("export: %w", err)
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("export: read %s: %w", ManifestFile, err)
+	}
+	if m.Pages == nil {
+		m.Pages = map[string]manifestEntry{}
+	}
+	return m, nil
+}
+
+func saveManifest(dir string, m manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	return writeFile(dir, ManifestFile, append(data, '\n'))
+}
+
+func writeFile(dir, rel string, data []byte) error {
+	target := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	return nil
+}
+
+// removeFile deletes a previously exported file and any directories left
+// empty by its removal. Paths escaping dir are ignored.
+func removeFile(dir, rel string) {
+	clean := path.Clean(rel)
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") || path.IsAbs(clean) {
+		return
+	}
+	if err := os.Remove(filepath.Join(dir, filepath.FromSlash(clean))); err != nil {
+		return
+	}
+	for parent := path.Dir(clean); parent != "."; parent = path.Dir(parent) {
+		if os.Remove(filepath.Join(dir, filepath.FromSlash(parent))) != nil {
+			return
+		}
+	}
+}
+
+// exportedPath reports whether a page path read from the manifest is one
+// assignPaths could have produced: slug segments ending in ".md". Anything
+// else, such as a hand-written README.md or .git/config, is never removed.
+func exportedPath(rel string) bool {
+	name, ok := strings.CutSuffix(rel, ".md")
+	if !ok || path.Clean(rel) != rel {
+		return false
+	}
+	for _, segment := range strings.Split(name, "/") {
+		if segment == "" || slug(segment) != segment {
+			return false
+		}
+	}
+	return true
+}
+
+// removeAttachments deletes the attachment directory of a page. IDs read from
+// the manifest are only trusted when numeric, so a tampered manifest cannot
+// point outside the attachments directory.
+func removeAttachments(dir, pageID string) error {
+	if !numericID.MatchString(pageID) {
+		return nil
+	}
+	base := filepath.Join(dir, AttachmentsDir)
+	target := filepath.Join(base, pageID)
+	if filepath.Dir(target) != base {
+		return nil
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	return nil
+}
+
+var numericID = regexp.MustCompile(`^\d+$`)
+
+// describeSource names what a manifest was exported from.
+func describeSource(spaceKey, rootID string) string {
+	if rootID != "" {
+		return "the page tree of " + rootID
+	}
+	return "space " + spaceKey
+}
+
+func exists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
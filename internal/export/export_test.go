@@ -0,0 +1,160 @@
+package export
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+	"github.com/ylchen07/atlassian-mcp/internal/config"
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	childVersion := "1"
+	hasChild := true
+	bodyFetches := 0
+
+	client, err := atlassian.NewHTTPClient("https://example.com", config.ServiceCredentials{Email: "a@example.com", APIToken: "token"})
+	if err != nil {
+		t.Fatalf("NewHTTPClient error: %v", err)
+	}
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := ""
+		expand := req.URL.Query().Get("expand")
+		switch req.URL.Path {
+		case "/rest/api/content/1":
+			if strings.Contains(expand, "body.storage") {
+				bodyFetches++
+				body = `{"id":"1","title":"Home","version":{"number":3},"body":{"storage":{"value":"<p>Welcome</p>"}}}`
+			} else {
+				body = `{"id":"1","title":"Home","version":{"number":3},"space":{"key":"OPS"}}`
+			}
+		case "/rest/api/content/1/child/page":
+			body = `{"results":[]}`
+			if hasChild {
+				body = `{"results":[{"id":"2","title":"Child: Page","version":{"number":` + childVersion + `}}]}`
+			}
+		case "/rest/api/content/2":
+			bodyFetches++
+			body = `{"id":"2","title":"Child: Page","version":{"number":` + childVersion + `},
+				"metadata":{"labels":{"results":[{"name":"runbook"}]}},
+				"body":{"storage":{"value":"<p>Back to <ac:link><ri:page ri:content-title=\"Home\" /></ac:link></p>"}}}`
+		case "/rest/api/content/2/child/page":
+			body = `{"results":[]}`
+		default:
+			t.Fatalf("unexpected request: %s", req.URL.String())
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+
+	dir := t.TempDir()
+	exporter := NewExporter(confluence.NewService(client))
+	opts := Options{RootID: "1", Dir: dir, BaseURL: "https://example.com/wiki"}
+
+	report, err := exporter.Export(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Export error: %v", err)
+	}
+	if report.Written != 2 || report.Pages[1].Path != "home/child-page.md" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "home", "child-page.md"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	for _, want := range []string{`title: "Child: Page"`, `id: "2"`, "version: 1", `labels: ["runbook"]`, `source: "https://example.com/wiki/pages/2"`, "Back to [Home](../home.md)"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("export missing %q:\n%s", want, data)
+		}
+	}
+
+	report, err = exporter.Export(context.Background(), opts)
+	if err != nil || report.Unchanged != 2 || bodyFetches != 2 {
+		t.Fatalf("expected unchanged re-run, got %+v (fetches %d, err %v)", report, bodyFetches, err)
+	}
+
+	childVersion = "2"
+	report, err = exporter.Export(context.Background(), opts)
+	if err != nil || report.Written != 1 || report.Pages[1].Status != StatusWritten {
+		t.Fatalf("expected child rewrite, got %+v (err %v)", report, err)
+	}
+
+	hasChild = false
+	report, err = exporter.Export(context.Background(), opts)
+	if err != nil || len(report.Removed) != 1 {
+		t.Fatalf("expected child removal, got %+v (err %v)", report, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "home")); !os.IsNotExist(err) {
+		t.Fatalf("expected empty child directory to be removed, got %v", err)
+	}
+
+	if _, err := exporter.Export(context.Background(), Options{SpaceKey: "OPS", Dir: dir}); err == nil || !strings.Contains(err.Error(), "page tree of 1") {
+		t.Fatalf("expected a space export into a page tree export to be refused, got %v", err)
+	}
+}
+
+func TestExportIgnoresTamperedManifest(t *testing.T) {
+	t.Parallel()
+
+	client, err := atlassian.NewHTTPClient("https://example.com", config.ServiceCredentials{Email: "a@example.com", APIToken: "token"})
+	if err != nil {
+		t.Fatalf("NewHTTPClient error: %v", err)
+	}
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"id":"1","title":"Home","version":{"number":1},"body":{"storage":{"value":"<p>Hi</p>"}}}`
+		if strings.HasSuffix(req.URL.Path, "/child/page") {
+			body = `{"results":[]}`
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+
+	outer := t.TempDir()
+	dir := filepath.Join(outer, "a", "b")
+	sentinel := filepath.Join(outer, "a", "keep.txt")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(sentinel, []byte("keep"), 0o644); err != nil {
+		t.Fatalf("write sentinel: %v", err)
+	}
+	handWritten := []string{"README.md", filepath.Join(".git", "config"), "notes.txt"}
+	for _, name := range handWritten {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(file, []byte("keep"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	tampered := `{"rootId":"1","pages":{"../..":{"path":"../keep.txt"},"2":{"path":".."},"3":{"path":"README.md"},"4":{"path":".git/config"},"5":{"path":"notes.txt"}}}`
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(tampered), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	if _, err := NewExporter(confluence.NewService(client)).Export(context.Background(), Options{RootID: "1", Dir: dir}); err != nil {
+		t.Fatalf("Export error: %v", err)
+	}
+	if _, err := os.Stat(sentinel); err != nil {
+		t.Fatalf("tampered manifest removed a file outside the export: %v", err)
+	}
+	for _, name := range handWritten {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("tampered manifest removed %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+)
+
+// Bulk operation types supported by BulkUpdate.
+const (
+	BulkSetField    = "setField"
+	BulkAddLabel    = "addLabel"
+	BulkRemoveLabel = "removeLabel"
+	BulkTransition  = "transition"
+	BulkAssign      = "assign"
+	BulkComment     = "comment"
+)
+
+const (
+	defaultBulkMaxIssues   = 100
+	maxBulkIssues          = 1000
+	defaultBulkConcurrency = 4
+	maxBulkConcurrency     = 10
+	maxRateLimitRetries    = 3
+)
+
+// BulkOperation describes the change applied to every selected issue.
+type BulkOperation struct {
+	Type       string
+	Field      string
+	Value      any
+	Label      string
+	Transition string
+	Fields     map[string]any
+	AccountID  string
+	Comment    any
+}
+
+// BulkRequest selects issues via JQL and applies an operation to each.
+type BulkRequest struct {
+	JQL         string
+	Operation   BulkOperation
+	DryRun      bool
+	MaxIssues   int
+	Concurrency int
+}
+
+// BulkItemResult reports the outcome for a single issue. Planned marks the
+// issues a dry run would change; nothing was applied to them.
+type BulkItemResult struct {
+	Key     string `json:"key"`
+	Success bool   `json:"success"`
+	Planned bool   `json:"planned,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkResult summarises a bulk run.
+type BulkResult struct {
+	DryRun    bool             `json:"dryRun"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Truncated bool             `json:"truncated"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// BulkUpdate applies an operation to every issue matched by the JQL with bounded
+// concurrency. Rate-limited requests are retried using the server's Retry-After hint.
+// With DryRun set, only the affected keys are reported.
+func (s *Service) BulkUpdate(ctx context.Context, req BulkRequest) (*BulkResult, error) {
+	if req.JQL == "" {
+		return nil, fmt.Errorf("jira: jql required")
+	}
+
+	apply, err := s.bulkApplier(req.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIssues := req.MaxIssues
+	if maxIssues <= 0 {
+		maxIssues = defaultBulkMaxIssues
+	}
+	if maxIssues > maxBulkIssues {
+		maxIssues = maxBulkIssues
+	}
+
+	issues, total, err := s.searchAll(ctx, SearchRequest{JQL: req.JQL, Fields: []string{"key"}}, maxIssues)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+
+	result := &BulkResult{
+		DryRun:    req.DryRun,
+		Total:     total,
+		Truncated: total > len(keys),
+		Results:   make([]BulkItemResult, len(keys)),
+	}
+
+	if req.DryRun {
+		for i, key := range keys {
+			result.Results[i] = BulkItemResult{Key: key, Planned: true}
+		}
+		return result, nil
+	}
+
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	if concurrency > maxBulkConcurrency {
+		concurrency = maxBulkConcurrency
+	}
+
+	forEachConcurrent(len(keys), concurrency, func(i int) {
+		item := BulkItemResult{Key: keys[i], Success: true}
+		if err := withRateLimitRetry(ctx, func() error { return apply(ctx, keys[i]) }); err != nil {
+			item.Success = false
+			item.Error = err.Error()
+		}
+		result.Results[i] = item
+	})
+
+	for _, item := range result.Results {
+		if item.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) bulkApplier(op BulkOperation) (func(context.Context, string) error, error) {
+	switch op.Type {
+	case BulkSetField:
+		if op.Field == "" {
+			return nil, fmt.Errorf("jira: field required for %s", op.Type)
+		}
+		return func(ctx context.Context, key string) error {
+			return s.UpdateIssue(ctx, key, map[string]any{op.Field: op.Value})
+		}, nil
+	case BulkAddLabel, BulkRemoveLabel:
+		if op.Label == "" {
+			return nil, fmt.Errorf("jira: label required for %s", op.Type)
+		}
+		return func(ctx context.Context, key string) error {
+			if op.Type == BulkAddLabel {
+				return s.UpdateLabels(ctx, key, []string{op.Label}, nil)
+			}
+			return s.UpdateLabels(ctx, key, nil, []string{op.Label})
+		}, nil
+	case BulkTransition:
+		if op.Transition == "" {
+			return nil, fmt.Errorf("jira: transition required for %s", op.Type)
+		}
+		return func(ctx context.Context, key string) error {
+			_, err := s.transitionIssueTo(ctx, key, op.Transition, op.Fields, false)
+			return err
+		}, nil
+	case BulkAssign:
+		return func(ctx context.Context, key string) error {
+			return s.AssignIssue(ctx, key, op.AccountID)
+		}, nil
+	case BulkComment:
+		if op.Comment == nil {
+			return nil, fmt.Errorf("jira: comment required for %s", op.Type)
+		}
+		return func(ctx context.Context, key string) error {
+			return s.AddComment(ctx, key, op.Comment)
+		}, nil
+	default:
+		return nil, fmt.Errorf("jira: unsupported bulk operation %q", op.Type)
+	}
+}
+
+// forEachConcurrent calls fn for every index in [0, n) using at most limit goroutines.
+func forEachConcurrent(n, limit int, fn func(i int)) {
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// withRateLimitRetry retries fn when Jira answers 429 Too Many Requests.
+func withRateLimitRetry(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+
+		var httpErr *atlassian.HTTPError
+		if attempt >= maxRateLimitRetries || !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+			return err
+		}
+
+		wait := httpErr.RetryAfter
+		if wait <= 0 {
+			wait = time.Duration(attempt+1) * time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
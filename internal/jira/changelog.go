@@ -0,0 +1,81 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+)
+
+const changelogPageSize = 100
+
+// timeLayout is the timestamp format used by the Jira REST API.
+const timeLayout = "2006-01-02T15:04:05.000-0700"
+
+// GetChangelog retrieves the complete change history of an issue, following
+// pagination. Sites without the paginated changelog endpoint (Data Center)
+// fall back to the issue `expand=changelog` representation.
+func (s *Service) GetChangelog(ctx context.Context, key string) ([]ChangelogEntry, error) {
+	if key == "" {
+		return nil, fmt.Errorf("jira: issue key required")
+	}
+
+	var entries []ChangelogEntry
+	for {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(len(entries)))
+		params.Set("maxResults", strconv.Itoa(changelogPageSize))
+
+		path := apiPath("issue", url.PathEscape(key), "changelog") + "?" + params.Encode()
+
+		var page struct {
+			Total  int              `json:"total"`
+			IsLast bool             `json:"isLast"`
+			Values []ChangelogEntry `json:"values"`
+		}
+		if err := s.client.Get(ctx, path, &page); err != nil {
+			if atlassian.IsStatus(err, http.StatusNotFound) && len(entries) == 0 {
+				return s.expandedChangelog(ctx, key)
+			}
+			return nil, err
+		}
+
+		entries = append(entries, page.Values...)
+		if page.IsLast || len(page.Values) == 0 || len(entries) >= page.Total {
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *Service) expandedChangelog(ctx context.Context, key string) ([]ChangelogEntry, error) {
+	params := url.Values{}
+	params.Set("expand", "changelog")
+	params.Set("fields", "created")
+
+	path := apiPath("issue", url.PathEscape(key)) + "?" + params.Encode()
+
+	var issue Issue
+	if err := s.client.Get(ctx, path, &issue); err != nil {
+		return nil, err
+	}
+
+	if issue.Changelog == nil {
+		return nil, nil
+	}
+
+	return issue.Changelog.Histories, nil
+}
+
+// ParseTime parses a Jira timestamp such as 2024-01-02T15:04:05.000+0000.
+func ParseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(timeLayout, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
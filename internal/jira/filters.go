@@ -0,0 +1,88 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+)
+
+// ListFilters searches saved filters by name. Sites without the filter search
+// endpoint (Data Center) fall back to the user's favourite filters.
+func (s *Service) ListFilters(ctx context.Context, name string, maxResults int) ([]Filter, error) {
+	if maxResults <= 0 {
+		maxResults = 50
+	}
+
+	params := url.Values{}
+	params.Set("expand", "description,jql,favourite,viewUrl,owner")
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	if name != "" {
+		params.Set("filterName", name)
+	}
+
+	var page struct {
+		Values []Filter `json:"values"`
+	}
+	err := s.client.Get(ctx, apiPath("filter", "search")+"?"+params.Encode(), &page)
+	if err == nil {
+		return page.Values, nil
+	}
+	if !atlassian.IsStatus(err, http.StatusNotFound) {
+		return nil, err
+	}
+
+	var favourites []Filter
+	if err := s.client.Get(ctx, apiPath("filter", "favourite"), &favourites); err != nil {
+		return nil, err
+	}
+
+	if len(favourites) > maxResults {
+		favourites = favourites[:maxResults]
+	}
+
+	return favourites, nil
+}
+
+// GetFilter retrieves a saved filter by ID.
+func (s *Service) GetFilter(ctx context.Context, id string) (*Filter, error) {
+	if id == "" {
+		return nil, fmt.Errorf("jira: filter id required")
+	}
+
+	var filter Filter
+	if err := s.client.Get(ctx, apiPath("filter", url.PathEscape(id)), &filter); err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+// CreateFilter saves a JQL query as a filter.
+func (s *Service) CreateFilter(ctx context.Context, in FilterInput) (*Filter, error) {
+	if in.Name == "" {
+		return nil, fmt.Errorf("jira: filter name required")
+	}
+	if in.JQL == "" {
+		return nil, fmt.Errorf("jira: jql required")
+	}
+
+	body := map[string]any{
+		"name":      in.Name,
+		"jql":       in.JQL,
+		"favourite": in.Favourite,
+	}
+	if in.Description != "" {
+		body["description"] = in.Description
+	}
+
+	var created Filter
+	if err := s.client.Post(ctx, apiPath("filter"), body, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
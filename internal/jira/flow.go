@@ -0,0 +1,370 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFlowMaxIssues = 200
+	maxFlowIssues        = 1000
+	flowConcurrency      = 4
+)
+
+// FlowOptions tunes how cycle time and completion are measured.
+type FlowOptions struct {
+	// StartStatuses mark the beginning of active work. When empty, cycle time
+	// starts at the first status change away from the initial status.
+	StartStatuses []string
+	// DoneStatuses mark completion for issues without a resolution date.
+	DoneStatuses []string
+}
+
+// FieldChange is a single field change flattened from the changelog.
+type FieldChange struct {
+	Time   time.Time `json:"time"`
+	Author string    `json:"author,omitempty"`
+	Field  string    `json:"field"`
+	From   string    `json:"from,omitempty"`
+	To     string    `json:"to,omitempty"`
+}
+
+// StatusPeriod is a span of time an issue spent in one status. End is zero
+// for the current status.
+type StatusPeriod struct {
+	Status   string        `json:"status"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+}
+
+// IssueFlow captures the history and derived flow timings of an issue.
+// LeadTime and CycleTime are zero while the issue is unresolved.
+type IssueFlow struct {
+	Key          string                   `json:"key"`
+	Status       string                   `json:"status"`
+	Created      time.Time                `json:"created"`
+	Started      time.Time                `json:"started"`
+	Resolved     time.Time                `json:"resolved"`
+	Changes      []FieldChange            `json:"changes"`
+	Periods      []StatusPeriod           `json:"periods"`
+	TimeInStatus map[string]time.Duration `json:"timeInStatus"`
+	LeadTime     time.Duration            `json:"leadTime"`
+	CycleTime    time.Duration            `json:"cycleTime"`
+}
+
+// DurationStats summarises a distribution of durations.
+type DurationStats struct {
+	Count int           `json:"count"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P85   time.Duration `json:"p85"`
+	P95   time.Duration `json:"p95"`
+	Max   time.Duration `json:"max"`
+}
+
+// WeeklyThroughput counts issues resolved in an ISO week (e.g. 2024-W05).
+type WeeklyThroughput struct {
+	Week  string `json:"week"`
+	Count int    `json:"count"`
+}
+
+// FlowMetricsRequest selects issues for aggregate flow metrics.
+type FlowMetricsRequest struct {
+	JQL       string
+	MaxIssues int
+	Options   FlowOptions
+}
+
+// FlowMetrics aggregates flow timings over a set of issues.
+type FlowMetrics struct {
+	Issues       int                      `json:"issues"`
+	Resolved     int                      `json:"resolved"`
+	Truncated    bool                     `json:"truncated"`
+	LeadTime     DurationStats            `json:"leadTime"`
+	CycleTime    DurationStats            `json:"cycleTime"`
+	TimeInStatus map[string]DurationStats `json:"timeInStatus"`
+	Throughput   []WeeklyThroughput       `json:"throughput"`
+	Failed       []FlowFailure            `json:"failed,omitempty"`
+}
+
+// FlowFailure names an issue whose history could not be loaded.
+type FlowFailure struct {
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+// IssueHistory returns the field changes and flow timings of a single issue.
+func (s *Service) IssueHistory(ctx context.Context, key string, opts FlowOptions) (*IssueFlow, error) {
+	issue, err := s.GetIssue(ctx, key, []string{"status", "created", "resolutiondate"})
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.GetChangelog(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputeIssueFlow(*issue, history, time.Now(), opts)
+}
+
+// FlowMetrics computes lead time, cycle time, time-in-status percentiles and
+// weekly throughput for the issues matched by a JQL query.
+func (s *Service) FlowMetrics(ctx context.Context, req FlowMetricsRequest) (*FlowMetrics, error) {
+	if req.JQL == "" {
+		return nil, fmt.Errorf("jira: jql required")
+	}
+
+	maxIssues := req.MaxIssues
+	if maxIssues <= 0 {
+		maxIssues = defaultFlowMaxIssues
+	}
+	if maxIssues > maxFlowIssues {
+		maxIssues = maxFlowIssues
+	}
+
+	issues, total, err := s.searchAll(ctx, SearchRequest{
+		JQL:    req.JQL,
+		Fields: []string{"status", "created", "resolutiondate"},
+		Expand: []string{"changelog"},
+	}, maxIssues)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	flows := make([]*IssueFlow, len(issues))
+	failures := make([]error, len(issues))
+
+	forEachConcurrent(len(issues), flowConcurrency, func(i int) {
+		issue := issues[i]
+
+		var history []ChangelogEntry
+		if issue.Changelog != nil {
+			history = issue.Changelog.Histories
+		}
+		// Search responses truncate long histories; fetch the rest explicitly.
+		if issue.Changelog == nil || issue.Changelog.Total > len(issue.Changelog.Histories) {
+			failures[i] = withRateLimitRetry(ctx, func() error {
+				var err error
+				history, err = s.GetChangelog(ctx, issue.Key)
+				return err
+			})
+			if failures[i] != nil {
+				return
+			}
+		}
+
+		flows[i], failures[i] = ComputeIssueFlow(issue, history, now, req.Options)
+	})
+
+	metrics := aggregateFlows(flows)
+	metrics.Truncated = total > len(issues)
+	for i, err := range failures {
+		if err != nil {
+			metrics.Failed = append(metrics.Failed, FlowFailure{Key: issues[i].Key, Error: err.Error()})
+		}
+	}
+
+	return metrics, nil
+}
+
+// ComputeIssueFlow derives status periods and flow timings from an issue and its changelog.
+func ComputeIssueFlow(issue Issue, history []ChangelogEntry, now time.Time, opts FlowOptions) (*IssueFlow, error) {
+	created, err := ParseTime(issue.Fields.Created)
+	if err != nil {
+		return nil, fmt.Errorf("jira: parse created time of %s: %w", issue.Key, err)
+	}
+
+	flow := &IssueFlow{
+		Key:          issue.Key,
+		Status:       issue.Fields.Status.Name,
+		Created:      created,
+		TimeInStatus: map[string]time.Duration{},
+	}
+
+	type statusChange struct {
+		at       time.Time
+		from, to string
+	}
+	var transitions []statusChange
+
+	for _, entry := range history {
+		at, err := ParseTime(entry.Created)
+		if err != nil {
+			return nil, fmt.Errorf("jira: parse changelog time of %s: %w", issue.Key, err)
+		}
+		for _, item := range entry.Items {
+			flow.Changes = append(flow.Changes, FieldChange{
+				Time:   at,
+				Author: entry.Author.DisplayName,
+				Field:  item.Field,
+				From:   item.FromString,
+				To:     item.ToString,
+			})
+			if item.Field == "status" {
+				transitions = append(transitions, statusChange{at: at, from: item.FromString, to: item.ToString})
+			}
+		}
+	}
+
+	sort.SliceStable(flow.Changes, func(i, j int) bool { return flow.Changes[i].Time.Before(flow.Changes[j].Time) })
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].at.Before(transitions[j].at) })
+
+	current := flow.Status
+	if len(transitions) > 0 {
+		current = transitions[0].from
+	}
+	start := created
+	for _, tr := range transitions {
+		flow.Periods = append(flow.Periods, StatusPeriod{Status: current, Start: start, End: tr.at, Duration: tr.at.Sub(start)})
+		current, start = tr.to, tr.at
+
+		if flow.Started.IsZero() && (len(opts.StartStatuses) == 0 || ContainsFold(opts.StartStatuses, tr.to)) {
+			flow.Started = tr.at
+		}
+	}
+
+	if issue.Fields.ResolutionDate != "" {
+		if resolved, err := ParseTime(issue.Fields.ResolutionDate); err == nil {
+			flow.Resolved = resolved
+		}
+	} else if ContainsFold(opts.DoneStatuses, current) {
+		flow.Resolved = start
+	}
+
+	// The final status of a resolved issue stops accruing time at resolution.
+	final := StatusPeriod{Status: current, Start: start, Duration: now.Sub(start)}
+	if !flow.Resolved.IsZero() {
+		final.End = flow.Resolved
+		if final.End.Before(start) {
+			final.End = start
+		}
+		final.Duration = final.End.Sub(start)
+	}
+	flow.Periods = append(flow.Periods, final)
+
+	for _, period := range flow.Periods {
+		flow.TimeInStatus[period.Status] += period.Duration
+	}
+
+	if !flow.Resolved.IsZero() {
+		flow.LeadTime = flow.Resolved.Sub(flow.Created)
+		if !flow.Started.IsZero() && flow.Started.Before(flow.Resolved) {
+			flow.CycleTime = flow.Resolved.Sub(flow.Started)
+		}
+	}
+
+	return flow, nil
+}
+
+func aggregateFlows(flows []*IssueFlow) *FlowMetrics {
+	metrics := &FlowMetrics{TimeInStatus: map[string]DurationStats{}}
+
+	var lead, cycle []time.Duration
+	inStatus := map[string][]time.Duration{}
+	weeks := map[string]int{}
+	var first, last time.Time
+
+	for _, flow := range flows {
+		if flow == nil {
+			continue
+		}
+		metrics.Issues++
+
+		for status, d := range flow.TimeInStatus {
+			inStatus[status] = append(inStatus[status], d)
+		}
+
+		if flow.Resolved.IsZero() {
+			continue
+		}
+		metrics.Resolved++
+		lead = append(lead, flow.LeadTime)
+		if flow.CycleTime > 0 {
+			cycle = append(cycle, flow.CycleTime)
+		}
+
+		weeks[isoWeek(flow.Resolved)]++
+		if first.IsZero() || flow.Resolved.Before(first) {
+			first = flow.Resolved
+		}
+		if flow.Resolved.After(last) {
+			last = flow.Resolved
+		}
+	}
+
+	metrics.LeadTime = summarizeDurations(lead)
+	metrics.CycleTime = summarizeDurations(cycle)
+	for status, durations := range inStatus {
+		metrics.TimeInStatus[status] = summarizeDurations(durations)
+	}
+
+	// Emit every week between the first and last resolution so gaps show as zero.
+	if !first.IsZero() {
+		for week := startOfWeek(first); !week.After(last); week = week.AddDate(0, 0, 7) {
+			label := isoWeek(week)
+			metrics.Throughput = append(metrics.Throughput, WeeklyThroughput{Week: label, Count: weeks[label]})
+		}
+	}
+
+	return metrics
+}
+
+func summarizeDurations(durations []time.Duration) DurationStats {
+	if len(durations) == 0 {
+		return DurationStats{}
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+
+	return DurationStats{
+		Count: len(sorted),
+		Mean:  sum / time.Duration(len(sorted)),
+		P50:   percentile(sorted, 50),
+		P85:   percentile(sorted, 85),
+		P95:   percentile(sorted, 95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile uses the nearest-rank method on an ascending slice.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	year, month, day := t.AddDate(0, 0, -offset).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// ContainsFold reports whether values holds target, ignoring case.
+func ContainsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
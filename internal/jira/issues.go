@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // SearchIssues executes a JQL search.
@@ -36,6 +37,60 @@ func (s *Service) SearchIssues(ctx context.Context, sr SearchRequest) (*SearchRe
 	return &result, nil
 }
 
+// searchAll pages through a JQL search collecting up to limit issues.
+func (s *Service) searchAll(ctx context.Context, sr SearchRequest, limit int) ([]Issue, int, error) {
+	var issues []Issue
+	total := 0
+
+	for len(issues) < limit {
+		pageSize := limit - len(issues)
+		if pageSize > 100 {
+			pageSize = 100
+		}
+
+		sr.StartAt = len(issues)
+		sr.MaxResults = pageSize
+
+		page, err := s.SearchIssues(ctx, sr)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		total = page.Total
+		issues = append(issues, page.Issues...)
+
+		if len(page.Issues) == 0 || len(issues) >= total {
+			break
+		}
+	}
+
+	return issues, total, nil
+}
+
+// GetIssue retrieves a single issue, optionally limited to the given fields.
+func (s *Service) GetIssue(ctx context.Context, key string, fields []string) (*Issue, error) {
+	if key == "" {
+		return nil, fmt.Errorf("jira: issue key required")
+	}
+
+	params := url.Values{}
+	if len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	}
+
+	path := apiPath("issue", url.PathEscape(key))
+	if encoded := params.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	var issue Issue
+	if err := s.client.Get(ctx, path, &issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
 // CreateIssue creates a new Jira issue and returns the created resource.
 func (s *Service) CreateIssue(ctx context.Context, input IssueInput) (*Issue, error) {
 	if input.ProjectKey == "" {
@@ -86,3 +141,43 @@ func (s *Service) UpdateIssue(ctx context.Context, key string, fields map[string
 
 	return s.client.Put(ctx, path, body, nil)
 }
+
+// UpdateLabels adds and removes labels on an issue without replacing the others.
+func (s *Service) UpdateLabels(ctx context.Context, key string, add, remove []string) error {
+	if key == "" {
+		return fmt.Errorf("jira: issue key required")
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return fmt.Errorf("jira: labels required")
+	}
+
+	ops := make([]map[string]string, 0, len(add)+len(remove))
+	for _, label := range add {
+		ops = append(ops, map[string]string{"add": label})
+	}
+	for _, label := range remove {
+		ops = append(ops, map[string]string{"remove": label})
+	}
+
+	body := map[string]any{"update": map[string]any{"labels": ops}}
+	path := apiPath("issue", url.PathEscape(key))
+
+	return s.client.Put(ctx, path, body, nil)
+}
+
+// AssignIssue assigns an issue to the given account ID. An empty account ID unassigns it.
+func (s *Service) AssignIssue(ctx context.Context, key, accountID string) error {
+	if key == "" {
+		return fmt.Errorf("jira: issue key required")
+	}
+
+	var assignee any
+	if accountID != "" {
+		assignee = accountID
+	}
+
+	body := map[string]any{"accountId": assignee}
+	path := apiPath("issue", url.PathEscape(key), "assignee")
+
+	return s.client.Put(ctx, path, body, nil)
+}
@@ -0,0 +1,104 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jqlPosition extracts the "line X, character Y" hint Jira appends to parse errors.
+var jqlPosition = regexp.MustCompile(`line (\d+), character (\d+)`)
+
+// ValidateJQL parses JQL queries with strict validation and reports errors
+// with their line and column positions.
+func (s *Service) ValidateJQL(ctx context.Context, queries ...string) ([]JQLValidation, error) {
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("jira: jql required")
+	}
+
+	body := map[string]any{"queries": queries}
+	path := apiPath("jql", "parse") + "?validation=strict"
+
+	var out struct {
+		Queries []struct {
+			Query  string   `json:"query"`
+			Errors []string `json:"errors"`
+		} `json:"queries"`
+	}
+	if err := s.client.Post(ctx, path, body, &out); err != nil {
+		return nil, err
+	}
+
+	results := make([]JQLValidation, 0, len(out.Queries))
+	for _, q := range out.Queries {
+		validation := JQLValidation{Query: q.Query, Valid: len(q.Errors) == 0}
+		for _, msg := range q.Errors {
+			validation.Errors = append(validation.Errors, parseJQLError(msg))
+		}
+		results = append(results, validation)
+	}
+
+	return results, nil
+}
+
+func parseJQLError(msg string) JQLError {
+	jqlErr := JQLError{Message: msg}
+	if m := jqlPosition.FindStringSubmatch(msg); m != nil {
+		jqlErr.Line, _ = strconv.Atoi(m[1])
+		jqlErr.Column, _ = strconv.Atoi(m[2])
+	}
+	return jqlErr
+}
+
+// JQLAutocomplete returns the fields, functions and reserved words usable in JQL.
+func (s *Service) JQLAutocomplete(ctx context.Context) (*JQLAutocompleteData, error) {
+	var data JQLAutocompleteData
+	if err := s.client.Get(ctx, apiPath("jql", "autocompletedata"), &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// JQLValueSuggestions suggests values for a field given a typed prefix.
+func (s *Service) JQLValueSuggestions(ctx context.Context, fieldName, prefix string) ([]JQLSuggestion, error) {
+	if fieldName == "" {
+		return nil, fmt.Errorf("jira: field name required")
+	}
+
+	params := url.Values{}
+	params.Set("fieldName", fieldName)
+	if prefix != "" {
+		params.Set("fieldValue", prefix)
+	}
+
+	path := apiPath("jql", "autocompletedata", "suggestions") + "?" + params.Encode()
+
+	var out struct {
+		Results []JQLSuggestion `json:"results"`
+	}
+	if err := s.client.Get(ctx, path, &out); err != nil {
+		return nil, err
+	}
+
+	return out.Results, nil
+}
+
+// MatchJQLFields filters autocomplete fields whose ID or display name contains prefix.
+func MatchJQLFields(fields []JQLField, prefix string) []JQLField {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return fields
+	}
+
+	var matches []JQLField
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f.Value), prefix) || strings.Contains(strings.ToLower(f.DisplayName), prefix) {
+			matches = append(matches, f)
+		}
+	}
+	return matches
+}
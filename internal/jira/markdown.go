@@ -0,0 +1,310 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TextToMarkdown renders an issue description or comment body as Markdown.
+// Bodies are Jira wiki markup strings in the v2 API and Atlassian Document
+// Format documents when a client created them through v3; both are
+// accepted. Wiki constructs without a Markdown equivalent are kept as text.
+func TextToMarkdown(body any) string {
+	switch v := body.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(wikiToMarkdown(v))
+	case map[string]any:
+		var b strings.Builder
+		adfBlocks(&b, adfContent(v))
+		return strings.TrimSpace(b.String())
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+var (
+	wikiHeading   = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiListItem  = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	wikiBlockOpen = regexp.MustCompile(`^\{(code|noformat)(?::([^}]*))?\}(.*)$`)
+	wikiMonospace = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiStrong    = regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*]*\S)?)\*($|[^\w*])`)
+	wikiEmphasis  = regexp.MustCompile(`(^|[^\w_])_(\S(?:[^_]*\S)?)_($|[^\w_])`)
+	wikiStrike    = regexp.MustCompile(`(^|\s)-(\S(?:[^-]*\S)?)-($|\s)`)
+	wikiLink      = regexp.MustCompile(`\[([^\[\]|]+)\|([^\[\]]+)\]`)
+	wikiBareLink  = regexp.MustCompile(`\[((?:https?|mailto):[^\[\]|]+)\]`)
+	wikiMention   = regexp.MustCompile(`\[~(?:accountid:)?([^\]]+)\]`)
+)
+
+func wikiToMarkdown(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var out []string
+	quote := false
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " ")
+		trimmed := strings.TrimSpace(line)
+
+		if m := wikiBlockOpen.FindStringSubmatch(trimmed); m != nil {
+			closing := "{" + m[1] + "}"
+			language := ""
+			if m[1] == "code" {
+				language = codeLanguage(m[2])
+			}
+			var body []string
+			rest := m[3]
+			for {
+				if end := strings.Index(rest, closing); end >= 0 {
+					if before := rest[:end]; strings.TrimSpace(before) != "" {
+						body = append(body, before)
+					}
+					break
+				}
+				if rest != "" || len(body) > 0 {
+					body = append(body, rest)
+				}
+				i++
+				if i >= len(lines) {
+					break
+				}
+				rest = strings.TrimRight(lines[i], "\r")
+			}
+			out = append(out, "```"+language)
+			out = append(out, body...)
+			out = append(out, "```")
+			continue
+		}
+
+		if trimmed == "{quote}" {
+			quote = !quote
+			continue
+		}
+
+		var converted string
+		switch {
+		case trimmed == "----":
+			converted = "---"
+		case strings.HasPrefix(trimmed, "bq. "):
+			converted = "> " + wikiInline(strings.TrimPrefix(trimmed, "bq. "))
+		case wikiHeading.MatchString(trimmed):
+			m := wikiHeading.FindStringSubmatch(trimmed)
+			converted = strings.Repeat("#", int(m[1][0]-'0')) + " " + wikiInline(m[2])
+		case strings.HasPrefix(trimmed, "||") || strings.HasPrefix(trimmed, "|"):
+			converted = wikiTableRow(trimmed)
+		case wikiListItem.MatchString(trimmed) && !strings.HasPrefix(trimmed, "--"):
+			m := wikiListItem.FindStringSubmatch(trimmed)
+			marker := "- "
+			if strings.HasSuffix(m[1], "#") {
+				marker = "1. "
+			}
+			converted = strings.Repeat("   ", len(m[1])-1) + marker + wikiInline(m[2])
+		default:
+			converted = wikiInline(line)
+		}
+
+		if quote {
+			converted = strings.TrimRight("> "+converted, " ")
+		}
+		out = append(out, converted)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// codeLanguage picks the language out of {code} parameters such as "java"
+// or "title=Example|language=go".
+func codeLanguage(params string) string {
+	for _, param := range strings.Split(params, "|") {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			return strings.TrimSpace(key)
+		}
+		if strings.TrimSpace(key) == "language" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func wikiTableRow(line string) string {
+	header := strings.HasPrefix(line, "||")
+	separator := "|"
+	if header {
+		separator = "||"
+	}
+	cells := strings.Split(strings.Trim(line, "|"), separator)
+	for i, cell := range cells {
+		cells[i] = wikiInline(strings.TrimSpace(strings.Trim(cell, "|")))
+	}
+	row := "| " + strings.Join(cells, " | ") + " |"
+	if header {
+		row += "\n|" + strings.Repeat(" --- |", len(cells))
+	}
+	return row
+}
+
+// wikiInline converts inline markup outside {{monospace}} spans.
+func wikiInline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range wikiMonospace.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(wikiFormat(text[last:m[0]]))
+		b.WriteString("`" + text[m[2]:m[3]] + "`")
+		last = m[1]
+	}
+	b.WriteString(wikiFormat(text[last:]))
+	return b.String()
+}
+
+func wikiFormat(text string) string {
+	text = wikiMention.ReplaceAllString(text, "@$1")
+	text = wikiLink.ReplaceAllString(text, "[$1]($2)")
+	text = wikiBareLink.ReplaceAllString(text, "<$1>")
+	text = wikiStrong.ReplaceAllString(text, "$1**$2**$3")
+	text = wikiEmphasis.ReplaceAllString(text, "$1*$2*$3")
+	text = wikiStrike.ReplaceAllString(text, "$1~~$2~~$3")
+	return text
+}
+
+// adfBlocks renders block nodes of an Atlassian Document Format document.
+func adfBlocks(b *strings.Builder, nodes []map[string]any) {
+	for _, node := range nodes {
+		switch adfString(node, "type") {
+		case "paragraph":
+			writeBlock(b, adfInline(adfContent(node)))
+		case "heading":
+			level, _ := adfAttrs(node)["level"].(float64)
+			if level < 1 {
+				level = 1
+			}
+			writeBlock(b, strings.Repeat("#", int(level))+" "+adfInline(adfContent(node)))
+		case "bulletList", "orderedList":
+			ordered := adfString(node, "type") == "orderedList"
+			for _, item := range adfContent(node) {
+				marker := "- "
+				if ordered {
+					marker = "1. "
+				}
+				var inner strings.Builder
+				adfBlocks(&inner, adfContent(item))
+				text := strings.TrimSpace(strings.ReplaceAll(inner.String(), "\n\n", "\n"))
+				text = strings.ReplaceAll(text, "\n", "\n"+strings.Repeat(" ", len(marker)))
+				b.WriteString(marker + text + "\n")
+			}
+			b.WriteString("\n")
+		case "codeBlock":
+			language := adfString(adfAttrs(node), "language")
+			writeBlock(b, "```"+language+"\n"+adfText(adfContent(node))+"\n```")
+		case "blockquote", "panel":
+			var inner strings.Builder
+			adfBlocks(&inner, adfContent(node))
+			writeBlock(b, quoteLines(strings.TrimSpace(inner.String())))
+		case "rule":
+			writeBlock(b, "---")
+		case "table":
+			var rows []string
+			for i, row := range adfContent(node) {
+				var cells []string
+				for _, cell := range adfContent(row) {
+					var inner strings.Builder
+					adfBlocks(&inner, adfContent(cell))
+					cells = append(cells, strings.ReplaceAll(strings.Join(strings.Fields(inner.String()), " "), "|", `\|`))
+				}
+				rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+				if i == 0 {
+					rows = append(rows, "|"+strings.Repeat(" --- |", len(cells)))
+				}
+			}
+			writeBlock(b, strings.Join(rows, "\n"))
+		default:
+			if content := adfContent(node); len(content) > 0 {
+				adfBlocks(b, content)
+			}
+		}
+	}
+}
+
+func writeBlock(b *strings.Builder, text string) {
+	b.WriteString(text + "\n\n")
+}
+
+func quoteLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func adfInline(nodes []map[string]any) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		attrs := adfAttrs(node)
+		switch adfString(node, "type") {
+		case "text":
+			b.WriteString(adfMarks(adfString(node, "text"), node))
+		case "hardBreak":
+			b.WriteString("  \n")
+		case "mention":
+			b.WriteString("@" + strings.TrimPrefix(adfString(attrs, "text"), "@"))
+		case "emoji":
+			b.WriteString(adfString(attrs, "shortName"))
+		case "inlineCard":
+			b.WriteString("<" + adfString(attrs, "url") + ">")
+		default:
+			b.WriteString(adfInline(adfContent(node)))
+		}
+	}
+	return b.String()
+}
+
+func adfMarks(text string, node map[string]any) string {
+	marks, _ := node["marks"].([]any)
+	for _, raw := range marks {
+		mark, _ := raw.(map[string]any)
+		switch adfString(mark, "type") {
+		case "code":
+			text = "`" + text + "`"
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "link":
+			text = "[" + text + "](" + adfString(adfAttrs(mark), "href") + ")"
+		}
+	}
+	return text
+}
+
+func adfText(nodes []map[string]any) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		b.WriteString(adfString(node, "text"))
+	}
+	return b.String()
+}
+
+func adfContent(node map[string]any) []map[string]any {
+	raw, _ := node["content"].([]any)
+	nodes := make([]map[string]any, 0, len(raw))
+	for _, item := range raw {
+		if child, ok := item.(map[string]any); ok {
+			nodes = append(nodes, child)
+		}
+	}
+	return nodes
+}
+
+func adfAttrs(node map[string]any) map[string]any {
+	attrs, _ := node["attrs"].(map[string]any)
+	return attrs
+}
+
+func adfString(node map[string]any, key string) string {
+	value, _ := node[key].(string)
+	return value
+}
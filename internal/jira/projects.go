@@ -2,6 +2,7 @@ package jira
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"strconv"
 )
@@ -25,3 +26,17 @@ func (s *Service) ListProjects(ctx context.Context, maxResults int) ([]Project,
 
 	return projects, nil
 }
+
+// GetProject retrieves a project by key or ID.
+func (s *Service) GetProject(ctx context.Context, key string) (*ProjectDetails, error) {
+	if key == "" {
+		return nil, fmt.Errorf("jira: project key required")
+	}
+
+	var project ProjectDetails
+	if err := s.client.Get(ctx, apiPath("project", url.PathEscape(key)), &project); err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
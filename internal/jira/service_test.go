@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
 	"github.com/ylchen07/atlassian-mcp/internal/config"
@@ -285,6 +287,544 @@ func TestTransitionIssue(t *testing.T) {
 	}
 }
 
+func TestTransitionIssueToByStatusName(t *testing.T) {
+	t.Parallel()
+
+	var posted map[string]any
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method == "POST" {
+			if err := json.NewDecoder(req.Body).Decode(&posted); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			return &http.Response{
+				StatusCode: 204,
+				Body:       io.NopCloser(bytes.NewReader([]byte(""))),
+				Header:     make(http.Header),
+			}, nil
+		}
+
+		data := []byte(`{"transitions":[
+			{"id":"11","name":"Start Progress","to":{"id":"3","name":"In Progress"}},
+			{"id":"31","name":"Resolve","to":{"id":"5","name":"Done"},
+			 "fields":{"resolution":{"name":"Resolution","required":true,"allowedValues":[{"id":"1","name":"Fixed"}]}}}
+		]}`)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	tr, err := service.TransitionIssueTo(context.Background(), "DEMO-1", "in progress", nil)
+	if err != nil {
+		t.Fatalf("TransitionIssueTo error: %v", err)
+	}
+
+	if tr.ID != "11" {
+		t.Fatalf("expected transition 11, got %s", tr.ID)
+	}
+
+	transition, _ := posted["transition"].(map[string]any)
+	if transition["id"] != "11" {
+		t.Fatalf("expected posted transition id 11, got %v", posted["transition"])
+	}
+}
+
+func TestTransitionIssueToMissingRequiredFields(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method == "POST" {
+			t.Fatalf("transition must not be executed when required fields are missing")
+		}
+
+		data := []byte(`{"transitions":[
+			{"id":"31","name":"Resolve","to":{"id":"5","name":"Done"},
+			 "fields":{"resolution":{"name":"Resolution","required":true,"allowedValues":[{"id":"1","name":"Fixed"}]}}}
+		]}`)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	_, err := service.TransitionIssueTo(context.Background(), "DEMO-1", "Done", nil)
+
+	var missing *MissingFieldsError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected MissingFieldsError, got %v", err)
+	}
+
+	if _, ok := missing.Fields["resolution"]; !ok {
+		t.Fatalf("expected resolution to be reported missing, got %v", missing.Fields)
+	}
+
+	if !strings.Contains(err.Error(), "Fixed") {
+		t.Fatalf("expected allowed values in error, got %v", err)
+	}
+}
+
+func TestShortestTransitionPath(t *testing.T) {
+	t.Parallel()
+
+	workflow := Workflow{
+		Statuses: []WorkflowStatus{
+			{ID: "1", Name: "To Do"},
+			{ID: "3", Name: "In Progress"},
+			{ID: "4", Name: "In Review"},
+			{ID: "5", Name: "Done"},
+		},
+		Transitions: []WorkflowTransition{
+			{ID: "1", Name: "Create", To: "1", Type: "initial"},
+			{ID: "11", Name: "Start", From: []string{"1"}, To: "3", Type: "directed"},
+			{ID: "21", Name: "Review", From: []string{"3"}, To: "4", Type: "directed"},
+			{ID: "31", Name: "Approve", From: []string{"4"}, To: "5", Type: "directed"},
+			{ID: "41", Name: "Reopen", To: "1", Type: "global"},
+		},
+	}
+
+	path, ok := ShortestTransitionPath(workflow, "to do", "DONE")
+	if !ok {
+		t.Fatal("expected a path to Done")
+	}
+
+	want := []TransitionStep{
+		{Transition: "Start", To: "In Progress"},
+		{Transition: "Review", To: "In Review"},
+		{Transition: "Approve", To: "Done"},
+	}
+	if len(path) != len(want) {
+		t.Fatalf("expected %d steps, got %v", len(want), path)
+	}
+	for i := range want {
+		if path[i] != want[i] {
+			t.Fatalf("step %d: expected %v, got %v", i, want[i], path[i])
+		}
+	}
+
+	if _, ok := ShortestTransitionPath(workflow, "To Do", "Unknown"); ok {
+		t.Fatal("expected no path to unknown status")
+	}
+}
+
+func TestBulkUpdateDryRun(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/rest/api/2/search") {
+			t.Fatalf("dry run must only search, got %s %s", req.Method, req.URL.Path)
+		}
+
+		data, _ := json.Marshal(SearchResult{
+			Total:  2,
+			Issues: []Issue{{Key: "DEMO-1"}, {Key: "DEMO-2"}},
+		})
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	result, err := service.BulkUpdate(context.Background(), BulkRequest{
+		JQL:       "project = DEMO",
+		Operation: BulkOperation{Type: BulkAddLabel, Label: "triaged"},
+		DryRun:    true,
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdate error: %v", err)
+	}
+
+	if !result.DryRun || len(result.Results) != 2 || result.Results[1].Key != "DEMO-2" {
+		t.Fatalf("unexpected dry run result: %+v", result)
+	}
+	if item := result.Results[0]; item.Success || !item.Planned || result.Succeeded != 0 {
+		t.Fatalf("dry run items must be planned, not successful: %+v", result)
+	}
+}
+
+func TestBulkUpdateReportsPerIssueFailures(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "/rest/api/2/search") {
+			data, _ := json.Marshal(SearchResult{
+				Total:  2,
+				Issues: []Issue{{Key: "DEMO-1"}, {Key: "DEMO-2"}},
+			})
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(data)),
+				Header:     make(http.Header),
+			}, nil
+		}
+
+		if req.Method != "PUT" {
+			t.Fatalf("expected PUT, got %s", req.Method)
+		}
+
+		status := 204
+		if strings.HasSuffix(req.URL.Path, "DEMO-2") {
+			status = 403
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewReader([]byte(""))),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	result, err := service.BulkUpdate(context.Background(), BulkRequest{
+		JQL:       "project = DEMO",
+		Operation: BulkOperation{Type: BulkRemoveLabel, Label: "stale"},
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdate error: %v", err)
+	}
+
+	if result.Succeeded != 1 || result.Failed != 1 {
+		t.Fatalf("expected 1 success and 1 failure, got %+v", result)
+	}
+
+	if result.Results[1].Success || !strings.Contains(result.Results[1].Error, "403") {
+		t.Fatalf("expected DEMO-2 to fail with 403, got %+v", result.Results[1])
+	}
+}
+
+func TestBulkUpdateTransitionSkipsPathSuggestion(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var data []byte
+		switch {
+		case strings.Contains(req.URL.Path, "/rest/api/2/search"):
+			data, _ = json.Marshal(SearchResult{
+				Total:  2,
+				Issues: []Issue{{Key: "DEMO-1"}, {Key: "DEMO-2"}},
+			})
+		case strings.HasSuffix(req.URL.Path, "/transitions"):
+			data = []byte(`{"transitions":[{"id":"11","name":"Start","to":{"name":"In Progress"}}]}`)
+		default:
+			t.Fatalf("bulk transitions must not look up workflows, got %s %s", req.Method, req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	result, err := service.BulkUpdate(context.Background(), BulkRequest{
+		JQL:       "project = DEMO",
+		Operation: BulkOperation{Type: BulkTransition, Transition: "Done"},
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdate error: %v", err)
+	}
+
+	if result.Failed != 2 || !strings.Contains(result.Results[0].Error, "Done") {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestBulkUpdateValidation(t *testing.T) {
+	t.Parallel()
+
+	client := &atlassian.HTTPClient{BaseURL: "https://example.com"}
+	service := NewService(client)
+
+	_, err := service.BulkUpdate(context.Background(), BulkRequest{
+		JQL:       "project = DEMO",
+		Operation: BulkOperation{Type: "delete"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported bulk operation") {
+		t.Fatalf("expected unsupported operation error, got %v", err)
+	}
+}
+
+func TestReleaseNotesGroupsByIssueType(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+
+		want := `project = "DEMO" AND fixVersion = "1.0 \"GA\"" ORDER BY key ASC`
+		if body["jql"] != want {
+			t.Fatalf("unexpected JQL: %v", body["jql"])
+		}
+
+		data := []byte(`{"total":3,"issues":[
+			{"key":"DEMO-1","fields":{"summary":"A","issuetype":{"name":"Story"}}},
+			{"key":"DEMO-2","fields":{"summary":"B","issuetype":{"name":"Bug"}}},
+			{"key":"DEMO-3","fields":{"summary":"C","issuetype":{"name":"Story"}}}
+		]}`)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	notes, err := service.ReleaseNotes(context.Background(), "DEMO", `1.0 "GA"`)
+	if err != nil {
+		t.Fatalf("ReleaseNotes error: %v", err)
+	}
+
+	if len(notes.Groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(notes.Groups))
+	}
+
+	if notes.Groups[0].IssueType != "Bug" || notes.Groups[1].IssueType != "Story" {
+		t.Fatalf("unexpected group order: %+v", notes.Groups)
+	}
+
+	if len(notes.Groups[1].Issues) != 2 {
+		t.Fatalf("expected 2 stories, got %d", len(notes.Groups[1].Issues))
+	}
+}
+
+func TestReleaseVersion(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.Method != "PUT" || !strings.HasSuffix(req.URL.Path, "/rest/api/2/version/10001") {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+
+		if body["released"] != true || body["releaseDate"] != "2024-05-01" {
+			t.Fatalf("unexpected body: %v", body)
+		}
+
+		data, _ := json.Marshal(Version{ID: "10001", Name: "1.0", Released: true, ReleaseDate: "2024-05-01"})
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	version, err := service.ReleaseVersion(context.Background(), "10001", "2024-05-01")
+	if err != nil {
+		t.Fatalf("ReleaseVersion error: %v", err)
+	}
+
+	if !version.Released {
+		t.Fatalf("expected version to be released")
+	}
+}
+
+func TestGetChangelogPaginates(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/rest/api/2/issue/DEMO-1/changelog") {
+			t.Fatalf("unexpected path: %s", req.URL.Path)
+		}
+
+		var data []byte
+		switch req.URL.Query().Get("startAt") {
+		case "0":
+			data = []byte(`{"total":2,"isLast":false,"values":[{"id":"1","created":"2024-01-01T10:00:00.000+0000"}]}`)
+		case "1":
+			data = []byte(`{"total":2,"isLast":true,"values":[{"id":"2","created":"2024-01-02T10:00:00.000+0000"}]}`)
+		default:
+			t.Fatalf("unexpected startAt: %s", req.URL.Query().Get("startAt"))
+		}
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	entries, err := service.GetChangelog(context.Background(), "DEMO-1")
+	if err != nil {
+		t.Fatalf("GetChangelog error: %v", err)
+	}
+
+	if len(entries) != 2 || entries[1].ID != "2" {
+		t.Fatalf("expected 2 entries across pages, got %+v", entries)
+	}
+}
+
+func TestComputeIssueFlow(t *testing.T) {
+	t.Parallel()
+
+	issue := Issue{Key: "DEMO-1"}
+	issue.Fields.Status.Name = "Done"
+	issue.Fields.Created = "2024-01-01T00:00:00.000+0000"
+	issue.Fields.ResolutionDate = "2024-01-04T00:00:00.000+0000"
+
+	history := []ChangelogEntry{
+		{Created: "2024-01-03T00:00:00.000+0000", Items: []ChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+		{Created: "2024-01-02T00:00:00.000+0000", Items: []ChangeItem{
+			{Field: "status", FromString: "To Do", ToString: "In Progress"},
+			{Field: "assignee", ToString: "Alex"},
+		}},
+	}
+
+	now := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	flow, err := ComputeIssueFlow(issue, history, now, FlowOptions{})
+	if err != nil {
+		t.Fatalf("ComputeIssueFlow error: %v", err)
+	}
+
+	day := 24 * time.Hour
+	if flow.LeadTime != 3*day {
+		t.Fatalf("expected lead time 3d, got %s", flow.LeadTime)
+	}
+	if flow.CycleTime != 2*day {
+		t.Fatalf("expected cycle time 2d, got %s", flow.CycleTime)
+	}
+	if flow.TimeInStatus["To Do"] != day || flow.TimeInStatus["In Progress"] != day || flow.TimeInStatus["Done"] != day {
+		t.Fatalf("unexpected time in status: %v", flow.TimeInStatus)
+	}
+	if len(flow.Changes) != 3 || flow.Changes[0].Field != "status" || flow.Changes[0].To != "In Progress" {
+		t.Fatalf("expected chronologically sorted changes, got %+v", flow.Changes)
+	}
+}
+
+func TestAggregateFlows(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+	monday := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	flows := []*IssueFlow{
+		{Resolved: monday, LeadTime: 1 * day, CycleTime: 1 * day, TimeInStatus: map[string]time.Duration{"To Do": day}},
+		{Resolved: monday.AddDate(0, 0, 1), LeadTime: 2 * day, CycleTime: 1 * day},
+		{Resolved: monday.AddDate(0, 0, 14), LeadTime: 10 * day},
+		{TimeInStatus: map[string]time.Duration{"To Do": 3 * day}},
+		nil,
+	}
+
+	metrics := aggregateFlows(flows)
+
+	if metrics.Issues != 4 || metrics.Resolved != 3 {
+		t.Fatalf("unexpected counts: %+v", metrics)
+	}
+	if metrics.LeadTime.P50 != 2*day || metrics.LeadTime.P95 != 10*day {
+		t.Fatalf("unexpected lead time stats: %+v", metrics.LeadTime)
+	}
+	if metrics.CycleTime.Count != 2 {
+		t.Fatalf("expected 2 cycle time samples, got %d", metrics.CycleTime.Count)
+	}
+	if metrics.TimeInStatus["To Do"].Max != 3*day {
+		t.Fatalf("unexpected time in status stats: %+v", metrics.TimeInStatus["To Do"])
+	}
+
+	want := []WeeklyThroughput{{Week: "2024-W01", Count: 2}, {Week: "2024-W02", Count: 0}, {Week: "2024-W03", Count: 1}}
+	if len(metrics.Throughput) != len(want) {
+		t.Fatalf("unexpected throughput: %+v", metrics.Throughput)
+	}
+	for i := range want {
+		if metrics.Throughput[i] != want[i] {
+			t.Fatalf("week %d: expected %+v, got %+v", i, want[i], metrics.Throughput[i])
+		}
+	}
+}
+
+func TestValidateJQLReportsPositions(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/rest/api/2/jql/parse") || req.URL.Query().Get("validation") != "strict" {
+			t.Fatalf("unexpected request: %s", req.URL.String())
+		}
+
+		data := []byte(`{"queries":[{"query":"project = ","errors":["Error in the JQL Query: Expecting either a value, list or function but got 'EOF'. You must surround 'EOF' in quotation marks to use it as a value. (line 1, character 11)"]}]}`)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	results, err := service.ValidateJQL(context.Background(), "project = ")
+	if err != nil {
+		t.Fatalf("ValidateJQL error: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Valid {
+		t.Fatalf("expected one invalid result, got %+v", results)
+	}
+
+	if results[0].Errors[0].Line != 1 || results[0].Errors[0].Column != 11 {
+		t.Fatalf("expected position 1:11, got %+v", results[0].Errors[0])
+	}
+}
+
+func TestListFiltersFallsBackToFavourites(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/filter/search") {
+			return &http.Response{
+				StatusCode: 404,
+				Body:       io.NopCloser(bytes.NewReader([]byte("Not Found"))),
+				Header:     make(http.Header),
+			}, nil
+		}
+
+		data, _ := json.Marshal([]Filter{{ID: "10000", Name: "My open issues", JQL: "assignee = currentUser()"}})
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	service := NewService(client)
+	filters, err := service.ListFilters(context.Background(), "", 10)
+	if err != nil {
+		t.Fatalf("ListFilters error: %v", err)
+	}
+
+	if len(filters) != 1 || filters[0].ID != "10000" {
+		t.Fatalf("expected favourite filter, got %+v", filters)
+	}
+}
+
+func TestTextToMarkdown(t *testing.T) {
+	t.Parallel()
+
+	wiki := "h2. Steps\n# Open *the* page\n## Click {{save_all}}\nSee [docs|https://example.com] -old- [~jdoe]\n{code:java}\nint a = *b*;\n{code}\n||Key||Value||\n|a|b|"
+	want := "## Steps\n1. Open **the** page\n   1. Click `save_all`\nSee [docs](https://example.com) ~~old~~ @jdoe\n```java\nint a = *b*;\n```\n| Key | Value |\n| --- | --- |\n| a | b |"
+	if got := TextToMarkdown(wiki); got != want {
+		t.Fatalf("unexpected wiki markdown:\n%s", got)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(`{"type":"doc","content":[
+		{"type":"paragraph","content":[{"type":"text","text":"bold","marks":[{"type":"strong"}]},{"type":"text","text":" and "},{"type":"text","text":"link","marks":[{"type":"link","attrs":{"href":"https://a"}}]}]},
+		{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]}]},
+		{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := 1"}]}]}`), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want = "**bold** and [link](https://a)\n\n- one\n\n```go\nx := 1\n```"
+	if got := TextToMarkdown(doc); got != want {
+		t.Fatalf("unexpected ADF markdown:\n%s", got)
+	}
+}
+
 func TestAPIPath(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 )
 
 // ListTransitions retrieves available workflow transitions for an issue.
@@ -50,3 +52,156 @@ func (s *Service) TransitionIssue(ctx context.Context, key, transitionID string,
 	path := apiPath("issue", url.PathEscape(key), "transitions")
 	return s.client.Post(ctx, path, body, nil)
 }
+
+// TransitionNotFoundError reports that no available transition leads to the
+// requested status. Path holds a suggested multi-step route when one could be
+// derived from the workflow definition.
+type TransitionNotFoundError struct {
+	Key       string
+	Target    string
+	Available []Transition
+	Path      []TransitionStep
+}
+
+func (e *TransitionNotFoundError) Error() string {
+	names := make([]string, 0, len(e.Available))
+	for _, tr := range e.Available {
+		names = append(names, fmt.Sprintf("%s (-> %s)", tr.Name, tr.To.Name))
+	}
+
+	msg := fmt.Sprintf("jira: no transition to %q available for %s", e.Target, e.Key)
+	if len(e.Path) > 0 {
+		steps := make([]string, 0, len(e.Path))
+		for _, step := range e.Path {
+			steps = append(steps, fmt.Sprintf("%s -> %s", step.Transition, step.To))
+		}
+		msg += "; suggested path: " + strings.Join(steps, ", then ")
+	}
+	if len(names) > 0 {
+		msg += "; available: " + strings.Join(names, ", ")
+	}
+
+	return msg
+}
+
+// MissingFieldsError reports required transition screen fields that were not supplied.
+type MissingFieldsError struct {
+	Transition string
+	Fields     map[string]TransitionField
+}
+
+func (e *MissingFieldsError) Error() string {
+	ids := make([]string, 0, len(e.Fields))
+	for id := range e.Fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		field := e.Fields[id]
+		part := id
+		if field.Name != "" && field.Name != id {
+			part = fmt.Sprintf("%s (%s)", id, field.Name)
+		}
+		if len(field.AllowedValues) > 0 {
+			values := make([]string, 0, len(field.AllowedValues))
+			for _, v := range field.AllowedValues {
+				values = append(values, v.Label())
+			}
+			part += " one of [" + strings.Join(values, ", ") + "]"
+		}
+		parts = append(parts, part)
+	}
+
+	return fmt.Sprintf("jira: transition %q requires fields: %s", e.Transition, strings.Join(parts, "; "))
+}
+
+// FindTransition resolves a transition ID, transition name or target status
+// name (case-insensitive) against the transitions available to an issue.
+func FindTransition(transitions []Transition, target string) (*Transition, bool) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return nil, false
+	}
+
+	for i := range transitions {
+		if transitions[i].ID == target {
+			return &transitions[i], true
+		}
+	}
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].Name, target) {
+			return &transitions[i], true
+		}
+	}
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].To.Name, target) {
+			return &transitions[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// MissingRequiredFields returns the required fields of a transition that have
+// no default value and are not present in fields.
+func MissingRequiredFields(tr Transition, fields map[string]any) map[string]TransitionField {
+	missing := map[string]TransitionField{}
+	for id, field := range tr.Fields {
+		if !field.Required || field.HasDefaultValue {
+			continue
+		}
+		if _, ok := fields[id]; ok {
+			continue
+		}
+		missing[id] = field
+	}
+	return missing
+}
+
+// TransitionIssueTo moves an issue to the named status or through the named
+// transition. Required transition screen fields must be supplied in fields;
+// when the status is not reachable in one step a suggested path is reported.
+func (s *Service) TransitionIssueTo(ctx context.Context, key, target string, fields map[string]any) (*Transition, error) {
+	return s.transitionIssueTo(ctx, key, target, fields, true)
+}
+
+// transitionIssueTo implements TransitionIssueTo. Bulk runs pass suggestPath
+// false so an unreachable target does not cost each issue a round of
+// workflow lookups.
+func (s *Service) transitionIssueTo(ctx context.Context, key, target string, fields map[string]any, suggestPath bool) (*Transition, error) {
+	if key == "" {
+		return nil, fmt.Errorf("jira: issue key required")
+	}
+	if strings.TrimSpace(target) == "" {
+		return nil, fmt.Errorf("jira: target status or transition required")
+	}
+
+	transitions, err := s.ListTransitions(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	tr, ok := FindTransition(transitions, target)
+	if !ok {
+		notFound := &TransitionNotFoundError{Key: key, Target: target, Available: transitions}
+		// Path suggestion relies on workflow admin endpoints; it is best effort.
+		if suggestPath {
+			if path, err := s.SuggestTransitionPath(ctx, key, target); err == nil {
+				notFound.Path = path
+			}
+		}
+		return nil, notFound
+	}
+
+	if missing := MissingRequiredFields(*tr, fields); len(missing) > 0 {
+		return nil, &MissingFieldsError{Transition: tr.Name, Fields: missing}
+	}
+
+	if err := s.TransitionIssue(ctx, key, tr.ID, fields); err != nil {
+		return nil, err
+	}
+
+	return tr, nil
+}
@@ -9,9 +9,10 @@ type Project struct {
 
 // Issue represents a simplified Jira issue payload.
 type Issue struct {
-	ID     string      `json:"id"`
-	Key    string      `json:"key"`
-	Fields IssueFields `json:"fields"`
+	ID        string      `json:"id"`
+	Key       string      `json:"key"`
+	Fields    IssueFields `json:"fields"`
+	Changelog *Changelog  `json:"changelog,omitempty"`
 }
 
 // IssueFields reflect the subset of issue fields we surface.
@@ -25,6 +26,53 @@ type IssueFields struct {
 		DisplayName string `json:"displayName"`
 		AccountID   string `json:"accountId"`
 	} `json:"assignee"`
+	Project struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	} `json:"project"`
+	IssueType struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"issuetype"`
+	Created        string `json:"created,omitempty"`
+	Updated        string `json:"updated,omitempty"`
+	ResolutionDate string `json:"resolutiondate,omitempty"`
+	Priority       *struct {
+		Name string `json:"name"`
+	} `json:"priority,omitempty"`
+	Reporter *struct {
+		DisplayName string `json:"displayName"`
+	} `json:"reporter,omitempty"`
+	Labels  []string `json:"labels,omitempty"`
+	Comment *struct {
+		Comments []IssueComment `json:"comments"`
+		Total    int            `json:"total"`
+	} `json:"comment,omitempty"`
+}
+
+// IssueComment is a comment embedded in an issue's comment field.
+type IssueComment struct {
+	ID     string `json:"id"`
+	Author struct {
+		DisplayName string `json:"displayName"`
+	} `json:"author"`
+	Body    any    `json:"body"`
+	Created string `json:"created"`
+}
+
+// ProjectDetails is a project with its lead, issue types and components.
+type ProjectDetails struct {
+	Project
+	Description    string `json:"description,omitempty"`
+	ProjectTypeKey string `json:"projectTypeKey,omitempty"`
+	Lead           struct {
+		DisplayName string `json:"displayName"`
+	} `json:"lead"`
+	IssueTypes []struct {
+		Name    string `json:"name"`
+		Subtask bool   `json:"subtask"`
+	} `json:"issueTypes,omitempty"`
+	Components []Component `json:"components,omitempty"`
 }
 
 // IssueInput represents fields for creating a new issue.
@@ -61,4 +109,219 @@ type Transition struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"to"`
+	Fields map[string]TransitionField `json:"fields,omitempty"`
+}
+
+// TransitionField describes a field on a transition screen.
+type TransitionField struct {
+	Name            string `json:"name"`
+	Required        bool   `json:"required"`
+	HasDefaultValue bool   `json:"hasDefaultValue"`
+	Schema          struct {
+		Type   string `json:"type"`
+		System string `json:"system"`
+	} `json:"schema"`
+	AllowedValues []FieldValue `json:"allowedValues,omitempty"`
+}
+
+// FieldValue represents an allowed value for a field (e.g. a resolution).
+type FieldValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Label returns the human readable name of the value.
+func (v FieldValue) Label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.Value
+}
+
+// TransitionStep is a single hop in a suggested multi-step workflow path.
+type TransitionStep struct {
+	Transition string `json:"transition"`
+	To         string `json:"to"`
+}
+
+// Workflow describes the statuses and transitions of a Jira workflow.
+type Workflow struct {
+	ID struct {
+		Name string `json:"name"`
+	} `json:"id"`
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// WorkflowStatus is a status that belongs to a workflow.
+type WorkflowStatus struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WorkflowTransition is an edge in a workflow graph. An empty From list
+// denotes a global transition available from every status.
+type WorkflowTransition struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	From []string `json:"from"`
+	To   string   `json:"to"`
+	Type string   `json:"type"`
+}
+
+// Version represents a project version (release).
+type Version struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ProjectID   int    `json:"projectId,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	Released    bool   `json:"released"`
+	Archived    bool   `json:"archived"`
+	Overdue     bool   `json:"overdue,omitempty"`
+}
+
+// VersionInput describes a version create/update request.
+// Nil pointers leave the corresponding flag unchanged on update.
+type VersionInput struct {
+	ProjectKey  string
+	Name        string
+	Description string
+	StartDate   string
+	ReleaseDate string
+	Released    *bool
+	Archived    *bool
+}
+
+// Component represents a project component.
+type Component struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Project      string `json:"project,omitempty"`
+	AssigneeType string `json:"assigneeType,omitempty"`
+	Lead         struct {
+		DisplayName string `json:"displayName"`
+		AccountID   string `json:"accountId"`
+	} `json:"lead"`
+}
+
+// ComponentInput describes a component create request.
+type ComponentInput struct {
+	ProjectKey    string
+	Name          string
+	Description   string
+	LeadAccountID string
+	AssigneeType  string
+}
+
+// ReleaseNotes groups the issues fixed in a version by issue type.
+type ReleaseNotes struct {
+	ProjectKey string             `json:"projectKey"`
+	Version    string             `json:"version"`
+	Total      int                `json:"total"`
+	Groups     []ReleaseNoteGroup `json:"groups"`
+}
+
+// ReleaseNoteGroup lists the issues of a single issue type.
+type ReleaseNoteGroup struct {
+	IssueType string  `json:"issueType"`
+	Issues    []Issue `json:"issues"`
+}
+
+// Changelog is the expanded issue history returned with `expand=changelog`.
+type Changelog struct {
+	StartAt    int              `json:"startAt"`
+	MaxResults int              `json:"maxResults"`
+	Total      int              `json:"total"`
+	Histories  []ChangelogEntry `json:"histories"`
+}
+
+// ChangelogEntry groups the field changes made by one edit.
+type ChangelogEntry struct {
+	ID     string `json:"id"`
+	Author struct {
+		DisplayName string `json:"displayName"`
+		AccountID   string `json:"accountId"`
+	} `json:"author"`
+	Created string       `json:"created"`
+	Items   []ChangeItem `json:"items"`
+}
+
+// ChangeItem is a single field change within a changelog entry.
+type ChangeItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
+}
+
+// Filter represents a saved Jira filter.
+type Filter struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	JQL         string `json:"jql"`
+	Favourite   bool   `json:"favourite"`
+	ViewURL     string `json:"viewUrl,omitempty"`
+	Owner       struct {
+		DisplayName string `json:"displayName"`
+		AccountID   string `json:"accountId"`
+	} `json:"owner"`
+}
+
+// FilterInput describes a filter create request.
+type FilterInput struct {
+	Name        string
+	JQL         string
+	Description string
+	Favourite   bool
+}
+
+// JQLValidation reports the parse result of a single JQL query.
+type JQLValidation struct {
+	Query  string     `json:"query"`
+	Valid  bool       `json:"valid"`
+	Errors []JQLError `json:"errors,omitempty"`
+}
+
+// JQLError is a parse error with its position when Jira reports one.
+type JQLError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// JQLAutocompleteData lists the fields, functions and reserved words usable in JQL.
+type JQLAutocompleteData struct {
+	Fields        []JQLField    `json:"visibleFieldNames"`
+	Functions     []JQLFunction `json:"visibleFunctionNames"`
+	ReservedWords []string      `json:"jqlReservedWords"`
+}
+
+// JQLField describes a field that can be referenced in JQL.
+type JQLField struct {
+	Value       string   `json:"value"`
+	DisplayName string   `json:"displayName"`
+	Orderable   string   `json:"orderable,omitempty"`
+	Searchable  string   `json:"searchable,omitempty"`
+	Operators   []string `json:"operators,omitempty"`
+	Types       []string `json:"types,omitempty"`
+}
+
+// JQLFunction describes a JQL function such as currentUser().
+type JQLFunction struct {
+	Value       string   `json:"value"`
+	DisplayName string   `json:"displayName"`
+	Types       []string `json:"types,omitempty"`
+}
+
+// JQLSuggestion is a value suggestion for a JQL field.
+type JQLSuggestion struct {
+	Value       string `json:"value"`
+	DisplayName string `json:"displayName"`
 }
@@ -0,0 +1,194 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/ylchen07/atlassian-mcp/internal/query"
+)
+
+const maxReleaseNoteIssues = 1000
+
+// ListVersions returns the versions defined for a project.
+func (s *Service) ListVersions(ctx context.Context, projectKey string) ([]Version, error) {
+	if projectKey == "" {
+		return nil, fmt.Errorf("jira: project key required")
+	}
+
+	var versions []Version
+	if err := s.client.Get(ctx, apiPath("project", url.PathEscape(projectKey), "versions"), &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// CreateVersion creates a version in the given project.
+func (s *Service) CreateVersion(ctx context.Context, in VersionInput) (*Version, error) {
+	if in.ProjectKey == "" {
+		return nil, fmt.Errorf("jira: project key required")
+	}
+	if in.Name == "" {
+		return nil, fmt.Errorf("jira: version name required")
+	}
+
+	body := versionPayload(in)
+	body["project"] = in.ProjectKey
+
+	var created Version
+	if err := s.client.Post(ctx, apiPath("version"), body, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateVersion updates the provided attributes of a version.
+func (s *Service) UpdateVersion(ctx context.Context, id string, in VersionInput) (*Version, error) {
+	if id == "" {
+		return nil, fmt.Errorf("jira: version id required")
+	}
+
+	body := versionPayload(in)
+	if len(body) == 0 {
+		return nil, fmt.Errorf("jira: version updates required")
+	}
+
+	var updated Version
+	if err := s.client.Put(ctx, apiPath("version", url.PathEscape(id)), body, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// ReleaseVersion marks a version as released. The release date defaults to today.
+func (s *Service) ReleaseVersion(ctx context.Context, id, releaseDate string) (*Version, error) {
+	if releaseDate == "" {
+		releaseDate = time.Now().Format("2006-01-02")
+	}
+
+	released := true
+	return s.UpdateVersion(ctx, id, VersionInput{ReleaseDate: releaseDate, Released: &released})
+}
+
+// ArchiveVersion archives or unarchives a version.
+func (s *Service) ArchiveVersion(ctx context.Context, id string, archived bool) (*Version, error) {
+	return s.UpdateVersion(ctx, id, VersionInput{Archived: &archived})
+}
+
+func versionPayload(in VersionInput) map[string]any {
+	body := map[string]any{}
+	if in.Name != "" {
+		body["name"] = in.Name
+	}
+	if in.Description != "" {
+		body["description"] = in.Description
+	}
+	if in.StartDate != "" {
+		body["startDate"] = in.StartDate
+	}
+	if in.ReleaseDate != "" {
+		body["releaseDate"] = in.ReleaseDate
+	}
+	if in.Released != nil {
+		body["released"] = *in.Released
+	}
+	if in.Archived != nil {
+		body["archived"] = *in.Archived
+	}
+	return body
+}
+
+// ListComponents returns the components defined for a project.
+func (s *Service) ListComponents(ctx context.Context, projectKey string) ([]Component, error) {
+	if projectKey == "" {
+		return nil, fmt.Errorf("jira: project key required")
+	}
+
+	var components []Component
+	if err := s.client.Get(ctx, apiPath("project", url.PathEscape(projectKey), "components"), &components); err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+// CreateComponent creates a component in the given project.
+func (s *Service) CreateComponent(ctx context.Context, in ComponentInput) (*Component, error) {
+	if in.ProjectKey == "" {
+		return nil, fmt.Errorf("jira: project key required")
+	}
+	if in.Name == "" {
+		return nil, fmt.Errorf("jira: component name required")
+	}
+
+	body := map[string]any{
+		"project": in.ProjectKey,
+		"name":    in.Name,
+	}
+	if in.Description != "" {
+		body["description"] = in.Description
+	}
+	if in.LeadAccountID != "" {
+		body["leadAccountId"] = in.LeadAccountID
+	}
+	if in.AssigneeType != "" {
+		body["assigneeType"] = in.AssigneeType
+	}
+
+	var created Component
+	if err := s.client.Post(ctx, apiPath("component"), body, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// ReleaseNotes gathers the issues with the given fix version and groups them by issue type.
+func (s *Service) ReleaseNotes(ctx context.Context, projectKey, version string) (*ReleaseNotes, error) {
+	if projectKey == "" {
+		return nil, fmt.Errorf("jira: project key required")
+	}
+	if version == "" {
+		return nil, fmt.Errorf("jira: version required")
+	}
+
+	jql, err := query.BuildJQL(query.JQLFilter{
+		Projects:    []string{projectKey},
+		FixVersions: []string{version},
+		OrderBy:     "key ASC",
+	})
+	if err != nil {
+		return nil, err
+	}
+	issues, total, err := s.searchAll(ctx, SearchRequest{
+		JQL:    jql,
+		Fields: []string{"summary", "status", "issuetype", "assignee"},
+	}, maxReleaseNoteIssues)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string][]Issue{}
+	for _, issue := range issues {
+		issueType := issue.Fields.IssueType.Name
+		if issueType == "" {
+			issueType = "Other"
+		}
+		groups[issueType] = append(groups[issueType], issue)
+	}
+
+	notes := &ReleaseNotes{ProjectKey: projectKey, Version: version, Total: total}
+	for issueType, grouped := range groups {
+		notes.Groups = append(notes.Groups, ReleaseNoteGroup{IssueType: issueType, Issues: grouped})
+	}
+	sort.Slice(notes.Groups, func(i, j int) bool {
+		return notes.Groups[i].IssueType < notes.Groups[j].IssueType
+	})
+
+	return notes, nil
+}
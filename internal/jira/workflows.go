@@ -0,0 +1,180 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GetWorkflow retrieves a workflow definition including statuses and transitions.
+// The endpoint requires Jira administration permissions on most sites.
+func (s *Service) GetWorkflow(ctx context.Context, name string) (*Workflow, error) {
+	if name == "" {
+		return nil, fmt.Errorf("jira: workflow name required")
+	}
+
+	params := url.Values{}
+	params.Set("workflowName", name)
+	params.Set("expand", "statuses,transitions")
+
+	path := apiPath("workflow", "search") + "?" + params.Encode()
+
+	var out struct {
+		Values []Workflow `json:"values"`
+	}
+	if err := s.client.Get(ctx, path, &out); err != nil {
+		return nil, err
+	}
+
+	for i := range out.Values {
+		if out.Values[i].ID.Name == name {
+			return &out.Values[i], nil
+		}
+	}
+	if len(out.Values) > 0 {
+		return &out.Values[0], nil
+	}
+
+	return nil, fmt.Errorf("jira: workflow %q not found", name)
+}
+
+// IssueWorkflowName resolves the workflow that governs an issue type in a project
+// using the project's workflow scheme.
+func (s *Service) IssueWorkflowName(ctx context.Context, projectID, issueTypeID string) (string, error) {
+	if projectID == "" {
+		return "", fmt.Errorf("jira: project id required")
+	}
+
+	params := url.Values{}
+	params.Set("projectId", projectID)
+
+	path := apiPath("workflowscheme", "project") + "?" + params.Encode()
+
+	var out struct {
+		Values []struct {
+			WorkflowScheme struct {
+				DefaultWorkflow   string            `json:"defaultWorkflow"`
+				IssueTypeMappings map[string]string `json:"issueTypeMappings"`
+			} `json:"workflowScheme"`
+		} `json:"values"`
+	}
+	if err := s.client.Get(ctx, path, &out); err != nil {
+		return "", err
+	}
+
+	if len(out.Values) == 0 {
+		return "", fmt.Errorf("jira: no workflow scheme for project %s", projectID)
+	}
+
+	scheme := out.Values[0].WorkflowScheme
+	if name, ok := scheme.IssueTypeMappings[issueTypeID]; ok && name != "" {
+		return name, nil
+	}
+	if scheme.DefaultWorkflow == "" {
+		return "", fmt.Errorf("jira: no workflow mapped for issue type %s", issueTypeID)
+	}
+
+	return scheme.DefaultWorkflow, nil
+}
+
+// SuggestTransitionPath computes the shortest sequence of transitions that moves
+// an issue from its current status to the target status.
+func (s *Service) SuggestTransitionPath(ctx context.Context, key, target string) ([]TransitionStep, error) {
+	issue, err := s.GetIssue(ctx, key, []string{"status", "project", "issuetype"})
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := s.IssueWorkflowName(ctx, issue.Fields.Project.ID, issue.Fields.IssueType.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.GetWorkflow(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	path, ok := ShortestTransitionPath(*workflow, issue.Fields.Status.Name, target)
+	if !ok {
+		return nil, fmt.Errorf("jira: status %q is not reachable from %q", target, issue.Fields.Status.Name)
+	}
+
+	return path, nil
+}
+
+// ShortestTransitionPath performs a breadth-first search over the workflow graph.
+// Statuses are matched by name, case-insensitively; target may also name a transition.
+func ShortestTransitionPath(workflow Workflow, from, target string) ([]TransitionStep, bool) {
+	names := make(map[string]string, len(workflow.Statuses))
+	var start string
+	goals := map[string]bool{}
+	for _, st := range workflow.Statuses {
+		names[st.ID] = st.Name
+		if strings.EqualFold(st.Name, from) {
+			start = st.ID
+		}
+		if strings.EqualFold(st.Name, target) {
+			goals[st.ID] = true
+		}
+	}
+	for _, tr := range workflow.Transitions {
+		if strings.EqualFold(tr.Name, target) {
+			goals[tr.To] = true
+		}
+	}
+
+	if start == "" || len(goals) == 0 {
+		return nil, false
+	}
+	if goals[start] {
+		return []TransitionStep{}, true
+	}
+
+	type hop struct {
+		prev       string
+		transition string
+	}
+	visited := map[string]hop{start: {}}
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, tr := range workflow.Transitions {
+			if _, seen := visited[tr.To]; seen || !transitionAppliesFrom(tr, current) {
+				continue
+			}
+			visited[tr.To] = hop{prev: current, transition: tr.Name}
+
+			if goals[tr.To] {
+				var steps []TransitionStep
+				for at := tr.To; at != start; at = visited[at].prev {
+					steps = append([]TransitionStep{{Transition: visited[at].transition, To: names[at]}}, steps...)
+				}
+				return steps, true
+			}
+
+			queue = append(queue, tr.To)
+		}
+	}
+
+	return nil, false
+}
+
+func transitionAppliesFrom(tr WorkflowTransition, status string) bool {
+	if tr.Type == "initial" {
+		return false
+	}
+	if len(tr.From) == 0 {
+		return true
+	}
+	for _, from := range tr.From {
+		if from == status {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+	"github.com/ylchen07/atlassian-mcp/internal/query"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -37,7 +38,7 @@ func NewConfluenceTools(s *server.MCPServer, service *confluence.Service, baseUR
 	s.AddTool(
 		mcp.NewTool(
 			"confluence.search_pages",
-			mcp.WithDescription("Search Confluence content using CQL"),
+			mcp.WithDescription("Search Confluence content using CQL or structured filters. Results include highlighted excerpts, space, last modification and breadcrumbs; page through with start or cursor"),
 			mcp.WithInputSchema[ConfluenceSearchArgs](),
 			mcp.WithOutputSchema[ConfluenceSearchResult](),
 		),
@@ -47,7 +48,7 @@ func NewConfluenceTools(s *server.MCPServer, service *confluence.Service, baseUR
 	s.AddTool(
 		mcp.NewTool(
 			"confluence.create_page",
-			mcp.WithDescription("Create a Confluence page in the specified space"),
+			mcp.WithDescription("Create a Confluence page, or a blog post with type blogpost, in the specified space"),
 			mcp.WithInputSchema[ConfluencePageArgs](),
 			mcp.WithOutputSchema[ConfluencePageResult](),
 		),
@@ -57,7 +58,7 @@ func NewConfluenceTools(s *server.MCPServer, service *confluence.Service, baseUR
 	s.AddTool(
 		mcp.NewTool(
 			"confluence.update_page",
-			mcp.WithDescription("Update an existing Confluence page"),
+			mcp.WithDescription("Update an existing Confluence page. The version is bumped automatically; pass expectedVersion to fail on concurrent edits"),
 			mcp.WithInputSchema[ConfluenceUpdateArgs](),
 			mcp.WithOutputSchema[ConfluencePageResult](),
 		),
@@ -67,13 +68,28 @@ func NewConfluenceTools(s *server.MCPServer, service *confluence.Service, baseUR
 	s.AddTool(
 		mcp.NewTool(
 			"confluence.get_page",
-			mcp.WithDescription("Retrieve a Confluence page by ID with full content"),
+			mcp.WithDescription("Retrieve a Confluence page with full content by ID, URL, or space key and title"),
 			mcp.WithInputSchema[ConfluenceGetPageArgs](),
 			mcp.WithOutputSchema[ConfluencePageDetailResult](),
 		),
 		mcp.NewTypedToolHandler(ct.handleGetPage),
 	)
 
+	ct.registerTreeTools(s)
+	ct.registerLifecycleTools(s)
+	ct.registerVersionTools(s)
+	ct.registerSectionTools(s)
+	ct.registerLabelTools(s)
+	ct.registerCommentTools(s)
+	ct.registerAttachmentTools(s)
+	ct.registerBlogTools(s)
+	ct.registerTemplateTools(s)
+	ct.registerRestrictionTools(s)
+	ct.registerCopyTools(s)
+	ct.registerSpaceTools(s)
+	ct.registerPropertyTools(s)
+	ct.registerExportTools(s)
+
 	return ct
 }
 
@@ -125,27 +141,84 @@ func (c *ConfluenceTools) handleListSpaces(ctx context.Context, _ mcp.CallToolRe
 
 // ConfluenceSearchArgs parameters for CQL search.
 type ConfluenceSearchArgs struct {
-	CQL   string `json:"cql" jsonschema:"required" jsonschema_description:"CQL query"`
-	Limit int    `json:"limit,omitempty" jsonschema_description:"Maximum results to return" jsonschema:"minimum=1,maximum=100"`
+	CQL     string                   `json:"cql,omitempty" jsonschema_description:"CQL query; required unless filters is given"`
+	Filters *ConfluenceSearchFilters `json:"filters,omitempty" jsonschema_description:"Structured filters compiled to CQL, as an alternative to cql"`
+	Limit   int                      `json:"limit,omitempty" jsonschema_description:"Maximum results to return" jsonschema:"minimum=1,maximum=100"`
+	Start   int                      `json:"start,omitempty" jsonschema_description:"Offset of the first result (Data Center pagination)" jsonschema:"minimum=0"`
+	Cursor  string                   `json:"cursor,omitempty" jsonschema_description:"Cursor from a previous response's nextCursor (Cloud pagination)"`
+	Expand  []string                 `json:"expand,omitempty" jsonschema_description:"Content expansions, e.g. space, version, history.lastUpdated, ancestors, body.view (default: everything but the body)"`
+}
+
+// ConfluenceSearchFilters are structured search filters.
+type ConfluenceSearchFilters struct {
+	Spaces         []string `json:"spaces,omitempty" jsonschema_description:"Space keys"`
+	Types          []string `json:"types,omitempty" jsonschema_description:"Content types, e.g. page, blogpost, attachment"`
+	Labels         []string `json:"labels,omitempty" jsonschema_description:"Labels the content must all carry"`
+	Text           string   `json:"text,omitempty" jsonschema_description:"Full-text search terms, matched literally"`
+	Title          string   `json:"title,omitempty" jsonschema_description:"Text the title must contain"`
+	Creator        string   `json:"creator,omitempty" jsonschema_description:"Author (account ID or username), or me for the configured account"`
+	Ancestor       string   `json:"ancestor,omitempty" jsonschema_description:"Page ID the content must be below"`
+	CreatedAfter   string   `json:"createdAfter,omitempty" jsonschema_description:"YYYY-MM-DD, an offset such as -7d, or a function such as startOfWeek()"`
+	CreatedBefore  string   `json:"createdBefore,omitempty" jsonschema_description:"Exclusive upper bound, same formats as createdAfter"`
+	ModifiedAfter  string   `json:"modifiedAfter,omitempty" jsonschema_description:"Last modified on or after, same formats as createdAfter"`
+	ModifiedBefore string   `json:"modifiedBefore,omitempty" jsonschema_description:"Last modified before, same formats as createdAfter"`
+	OrderBy        string   `json:"orderBy,omitempty" jsonschema_description:"Sort order, e.g. lastmodified DESC"`
 }
 
 // ConfluencePageSummary summarises content results.
 type ConfluencePageSummary struct {
-	ID      string `json:"id"`
-	Title   string `json:"title"`
-	Type    string `json:"type"`
-	Status  string `json:"status"`
-	Version int    `json:"version"`
-	URL     string `json:"url"`
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Type           string   `json:"type"`
+	Status         string   `json:"status"`
+	Version        int      `json:"version"`
+	SpaceKey       string   `json:"spaceKey,omitempty"`
+	SpaceName      string   `json:"spaceName,omitempty"`
+	Excerpt        string   `json:"excerpt,omitempty"`
+	LastModified   string   `json:"lastModified,omitempty"`
+	LastModifiedBy string   `json:"lastModifiedBy,omitempty"`
+	Breadcrumbs    []string `json:"breadcrumbs,omitempty"`
+	URL            string   `json:"url"`
 }
 
 // ConfluenceSearchResult search response payload.
 type ConfluenceSearchResult struct {
-	Results []ConfluencePageSummary `json:"results"`
+	Results    []ConfluencePageSummary `json:"results"`
+	CQL        string                  `json:"cql,omitempty"`
+	TotalSize  int                     `json:"totalSize,omitempty"`
+	HasMore    bool                    `json:"hasMore,omitempty"`
+	NextStart  int                     `json:"nextStart,omitempty"`
+	NextCursor string                  `json:"nextCursor,omitempty"`
 }
 
 func (c *ConfluenceTools) handleSearchContent(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceSearchArgs) (*mcp.CallToolResult, error) {
-	if strings.TrimSpace(args.CQL) == "" {
+	cql := args.CQL
+	if args.Filters != nil {
+		if strings.TrimSpace(cql) != "" {
+			return mcp.NewToolResultError("provide either cql or filters, not both"), nil
+		}
+
+		f := args.Filters
+		built, err := query.BuildCQL(query.CQLFilter{
+			Spaces:         f.Spaces,
+			Types:          f.Types,
+			Labels:         f.Labels,
+			Text:           f.Text,
+			Title:          f.Title,
+			Creator:        f.Creator,
+			Ancestor:       f.Ancestor,
+			CreatedAfter:   f.CreatedAfter,
+			CreatedBefore:  f.CreatedBefore,
+			ModifiedAfter:  f.ModifiedAfter,
+			ModifiedBefore: f.ModifiedBefore,
+			OrderBy:        f.OrderBy,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid search filters", err), nil
+		}
+		cql = built
+	}
+	if strings.TrimSpace(cql) == "" {
 		return mcp.NewToolResultError("CQL query must not be empty"), nil
 	}
 
@@ -154,43 +227,134 @@ func (c *ConfluenceTools) handleSearchContent(ctx context.Context, _ mcp.CallToo
 		limit = 25
 	}
 
-	results, err := c.service.SearchContent(ctx, args.CQL, limit)
+	page, err := c.service.Search(ctx, confluence.SearchQuery{
+		CQL:    cql,
+		Limit:  limit,
+		Start:  args.Start,
+		Cursor: args.Cursor,
+		Expand: args.Expand,
+	})
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("confluence search failed", err), nil
 	}
 
-	payload := ConfluenceSearchResult{Results: make([]ConfluencePageSummary, 0, len(results))}
-	for _, content := range results {
-		payload.Results = append(payload.Results, ConfluencePageSummary{
-			ID:      content.ID,
-			Title:   content.Title,
-			Type:    content.Type,
-			Status:  content.Status,
-			Version: content.Version.Number,
-			URL:     fmt.Sprintf("%s/pages/%s", c.baseURL, content.ID),
-		})
+	payload := ConfluenceSearchResult{
+		Results:   make([]ConfluencePageSummary, 0, len(page.Results)),
+		CQL:       cql,
+		TotalSize: page.TotalSize,
+		HasMore:   page.HasMore,
+	}
+	if page.HasMore {
+		payload.NextStart = page.NextStart
+		payload.NextCursor = page.NextCursor
+	}
+	for _, hit := range page.Results {
+		payload.Results = append(payload.Results, c.toSearchSummary(hit))
+	}
+
+	return mcp.NewToolResultStructured(payload, renderSearchResults(payload)), nil
+}
+
+func (c *ConfluenceTools) toSearchSummary(hit confluence.SearchResult) ConfluencePageSummary {
+	summary := ConfluencePageSummary{
+		Title:        hit.Title,
+		Type:         hit.EntityType,
+		SpaceKey:     hit.SpaceKey,
+		SpaceName:    hit.Container.Title,
+		Excerpt:      hit.Excerpt,
+		LastModified: hit.LastModified,
+		URL:          c.baseURL + hit.URL,
+	}
+
+	if content := hit.Content; content != nil {
+		summary.ID = content.ID
+		summary.Type = content.Type
+		summary.Status = content.Status
+		summary.Version = content.Version.Number
+		summary.URL = c.pageURL(content.ID)
+		if content.Space != nil && content.Space.Name != "" {
+			summary.SpaceName = content.Space.Name
+		}
+		if content.History != nil && content.History.LastUpdated != nil {
+			if summary.LastModified == "" {
+				summary.LastModified = content.History.LastUpdated.When
+			}
+			summary.LastModifiedBy = content.History.LastUpdated.By.DisplayName
+		}
+		for _, ancestor := range content.Ancestors {
+			summary.Breadcrumbs = append(summary.Breadcrumbs, ancestor.Title)
+		}
+	}
+
+	if len(summary.Breadcrumbs) == 0 {
+		for _, crumb := range hit.Breadcrumbs {
+			summary.Breadcrumbs = append(summary.Breadcrumbs, crumb.Label)
+		}
+	}
+
+	return summary
+}
+
+func renderSearchResults(result ConfluenceSearchResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d Confluence results", len(result.Results))
+	if result.TotalSize > len(result.Results) {
+		fmt.Fprintf(&b, " of %d", result.TotalSize)
 	}
 
-	fallback := fmt.Sprintf("Found %d Confluence results", len(payload.Results))
-	return mcp.NewToolResultStructured(payload, fallback), nil
+	for _, hit := range result.Results {
+		fmt.Fprintf(&b, "\n- %s", hit.Title)
+		if hit.ID != "" {
+			fmt.Fprintf(&b, " (%s)", hit.ID)
+		}
+		if hit.SpaceKey != "" {
+			fmt.Fprintf(&b, " in %s", hit.SpaceKey)
+		}
+		if len(hit.Breadcrumbs) > 0 {
+			fmt.Fprintf(&b, " > %s", strings.Join(hit.Breadcrumbs, " > "))
+		}
+		if hit.LastModified != "" {
+			fmt.Fprintf(&b, ", modified %s", hit.LastModified)
+			if hit.LastModifiedBy != "" {
+				fmt.Fprintf(&b, " by %s", hit.LastModifiedBy)
+			}
+		}
+		if hit.Excerpt != "" {
+			fmt.Fprintf(&b, "\n  %s", hit.Excerpt)
+		}
+	}
+
+	switch {
+	case result.NextCursor != "":
+		fmt.Fprintf(&b, "\nMore results: pass cursor %s", result.NextCursor)
+	case result.HasMore:
+		fmt.Fprintf(&b, "\nMore results: pass start %d", result.NextStart)
+	}
+
+	return b.String()
 }
 
 // ConfluencePageArgs parameters for page creation.
 type ConfluencePageArgs struct {
-	SpaceKey string `json:"spaceKey" jsonschema:"required" jsonschema_description:"Space key"`
-	Title    string `json:"title" jsonschema:"required" jsonschema_description:"Page title"`
-	Body     string `json:"body" jsonschema:"required" jsonschema_description:"Page body in storage format"`
-	ParentID string `json:"parentId,omitempty" jsonschema_description:"Ancestor page ID"`
+	Type                   string   `json:"type,omitempty" jsonschema:"enum=page,enum=blogpost" jsonschema_description:"Content type (default page)"`
+	SpaceKey               string   `json:"spaceKey" jsonschema:"required" jsonschema_description:"Space key"`
+	Title                  string   `json:"title" jsonschema:"required" jsonschema_description:"Page title"`
+	Body                   string   `json:"body" jsonschema:"required" jsonschema_description:"Page body in storage format"`
+	ParentID               string   `json:"parentId,omitempty" jsonschema_description:"Ancestor page ID (pages only)"`
+	Labels                 []string `json:"labels,omitempty" jsonschema_description:"Labels to apply to the new page"`
+	CopyParentRestrictions bool     `json:"copyParentRestrictions,omitempty" jsonschema_description:"Apply the parent page's view and edit restrictions to the new page"`
 }
 
 // ConfluenceUpdateArgs parameters for page update.
 type ConfluenceUpdateArgs struct {
-	ID       string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
-	SpaceKey string `json:"spaceKey,omitempty" jsonschema_description:"Space key"`
-	Title    string `json:"title" jsonschema:"required" jsonschema_description:"Page title"`
-	Body     string `json:"body" jsonschema:"required" jsonschema_description:"Page body in storage format"`
-	ParentID string `json:"parentId,omitempty" jsonschema_description:"Ancestor page ID"`
-	Version  int    `json:"version" jsonschema:"required" jsonschema_description:"Next version number"`
+	ID              string `json:"id" jsonschema:"required" jsonschema_description:"Page or blog post ID"`
+	Type            string `json:"type,omitempty" jsonschema:"enum=page,enum=blogpost" jsonschema_description:"Content type (default: keep the current type)"`
+	SpaceKey        string `json:"spaceKey,omitempty" jsonschema_description:"Space key"`
+	Title           string `json:"title,omitempty" jsonschema_description:"Page title (default: keep the current title)"`
+	Body            string `json:"body" jsonschema:"required" jsonschema_description:"Page body in storage format"`
+	ParentID        string `json:"parentId,omitempty" jsonschema_description:"Ancestor page ID"`
+	Version         int    `json:"version,omitempty" jsonschema_description:"Explicit next version number; the update fails with a conflict and diff unless the page is at version-1. Omit to bump the current version automatically"`
+	ExpectedVersion int    `json:"expectedVersion,omitempty" jsonschema_description:"Version the edit is based on; fails with a conflict and diff if the page has changed since"`
 }
 
 // ConfluencePageResult response for create/update.
@@ -203,8 +367,12 @@ type ConfluencePageResult struct {
 
 // ConfluenceGetPageArgs parameters for retrieving a page.
 type ConfluenceGetPageArgs struct {
-	ID     string   `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
-	Expand []string `json:"expand,omitempty" jsonschema_description:"Additional content expansions (e.g., body.storage, version, space)"`
+	ID         string   `json:"id,omitempty" jsonschema_description:"Page ID"`
+	URL        string   `json:"url,omitempty" jsonschema_description:"Page or blog post link (Cloud /spaces/KEY/pages/ID, Data Center /display/KEY/Title or tiny /x/AbCd) as an alternative to id"`
+	SpaceKey   string   `json:"spaceKey,omitempty" jsonschema_description:"Space key; combine with title as an alternative to id"`
+	Title      string   `json:"title,omitempty" jsonschema_description:"Exact page title within spaceKey"`
+	PostingDay string   `json:"postingDay,omitempty" jsonschema_description:"Blog post date (YYYY-MM-DD); with spaceKey and title, looks up a blog post instead of a page"`
+	Expand     []string `json:"expand,omitempty" jsonschema_description:"Additional content expansions (e.g., body.storage, version, space)"`
 }
 
 // ConfluencePageDetailResult response for get page with full content.
@@ -220,10 +388,13 @@ type ConfluencePageDetailResult struct {
 
 func (c *ConfluenceTools) handleCreatePage(ctx context.Context, _ mcp.CallToolRequest, args ConfluencePageArgs) (*mcp.CallToolResult, error) {
 	created, err := c.service.CreatePage(ctx, confluence.PageInput{
-		SpaceKey: args.SpaceKey,
-		Title:    args.Title,
-		Body:     args.Body,
-		ParentID: args.ParentID,
+		Type:                   args.Type,
+		SpaceKey:               args.SpaceKey,
+		Title:                  args.Title,
+		Body:                   args.Body,
+		ParentID:               args.ParentID,
+		Labels:                 args.Labels,
+		CopyParentRestrictions: args.CopyParentRestrictions,
 	})
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("confluence create page failed", err), nil
@@ -236,17 +407,31 @@ func (c *ConfluenceTools) handleCreatePage(ctx context.Context, _ mcp.CallToolRe
 		URL:     fmt.Sprintf("%s/pages/%s", c.baseURL, created.ID),
 	}
 
-	fallback := fmt.Sprintf("Created Confluence page %s", created.Title)
+	kind := "page"
+	if created.Type == confluence.TypeBlogPost {
+		kind = "blog post"
+	}
+	fallback := fmt.Sprintf("Created Confluence %s %s", kind, created.Title)
 	return mcp.NewToolResultStructured(result, fallback), nil
 }
 
 func (c *ConfluenceTools) handleUpdatePage(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceUpdateArgs) (*mcp.CallToolResult, error) {
-	updated, err := c.service.UpdatePage(ctx, args.ID, confluence.PageInput{
-		SpaceKey: args.SpaceKey,
-		Title:    args.Title,
-		Body:     args.Body,
-		ParentID: args.ParentID,
-		Version:  args.Version,
+	// An explicit next version pins the edit to the version before it.
+	expected := args.ExpectedVersion
+	if args.Version > 0 {
+		if expected > 0 && expected != args.Version-1 {
+			return mcp.NewToolResultError(fmt.Sprintf("version %d does not follow expectedVersion %d", args.Version, expected)), nil
+		}
+		expected = args.Version - 1
+	}
+
+	updated, err := c.service.EditPage(ctx, args.ID, confluence.PageEdit{
+		Type:            args.Type,
+		SpaceKey:        args.SpaceKey,
+		Title:           args.Title,
+		Body:            args.Body,
+		ParentID:        args.ParentID,
+		ExpectedVersion: expected,
 	})
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("confluence update page failed", err), nil
@@ -270,7 +455,23 @@ func (c *ConfluenceTools) handleGetPage(ctx context.Context, _ mcp.CallToolReque
 		expand = []string{"body.storage", "version", "space"}
 	}
 
-	page, err := c.service.GetPage(ctx, args.ID, expand)
+	var ref confluence.PageRef
+	switch {
+	case args.ID != "":
+		ref = confluence.PageRef{ID: args.ID}
+	case args.URL != "":
+		parsed, err := confluence.ParsePageURL(c.baseURL, args.URL)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence get page failed", err), nil
+		}
+		ref = parsed
+	case args.SpaceKey != "" && args.Title != "":
+		ref = confluence.PageRef{SpaceKey: args.SpaceKey, Title: args.Title, PostingDay: args.PostingDay}
+	default:
+		return mcp.NewToolResultError("one of id, url, or spaceKey and title must be provided"), nil
+	}
+
+	page, err := c.service.ResolvePage(ctx, ref, expand)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("confluence get page failed", err), nil
 	}
@@ -0,0 +1,230 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultAttachmentBytes = 1 << 20
+	maxAttachmentBytes     = 10 << 20
+)
+
+// registerAttachmentTools registers page attachment tools.
+func (c *ConfluenceTools) registerAttachmentTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.upload_attachment",
+			mcp.WithDescription("Attach a file to a Confluence page; uploads a new version if a file with the same name exists. Image uploads return ac:image markup for embedding"),
+			mcp.WithInputSchema[ConfluenceUploadAttachmentArgs](),
+			mcp.WithOutputSchema[ConfluenceAttachment](),
+		),
+		mcp.NewTypedToolHandler(c.handleUploadAttachment),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.list_attachments",
+			mcp.WithDescription("List the attachments of a Confluence page"),
+			mcp.WithInputSchema[ConfluencePageIDArgs](),
+			mcp.WithOutputSchema[ConfluenceListAttachmentsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleListAttachments),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.download_attachment",
+			mcp.WithDescription("Download a page attachment as an embedded resource (text for text types, base64 otherwise)"),
+			mcp.WithInputSchema[ConfluenceDownloadAttachmentArgs](),
+		),
+		mcp.NewTypedToolHandler(c.handleDownloadAttachment),
+	)
+}
+
+// ConfluenceUploadAttachmentArgs parameters for uploading an attachment.
+type ConfluenceUploadAttachmentArgs struct {
+	ID        string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	FileName  string `json:"fileName" jsonschema:"required" jsonschema_description:"Attachment file name"`
+	Data      string `json:"data" jsonschema:"required" jsonschema_description:"Base64-encoded file contents"`
+	Comment   string `json:"comment,omitempty" jsonschema_description:"Attachment version comment"`
+	MinorEdit bool   `json:"minorEdit,omitempty" jsonschema_description:"Suppress watcher notifications"`
+}
+
+// ConfluenceAttachment describes an attachment.
+type ConfluenceAttachment struct {
+	ID          string `json:"id"`
+	FileName    string `json:"fileName"`
+	MediaType   string `json:"mediaType,omitempty"`
+	FileSize    int64  `json:"fileSize,omitempty"`
+	Version     int    `json:"version"`
+	Comment     string `json:"comment,omitempty"`
+	DownloadURL string `json:"downloadUrl,omitempty"`
+	ImageMarkup string `json:"imageMarkup,omitempty"`
+}
+
+// ConfluenceListAttachmentsResult lists a page's attachments.
+type ConfluenceListAttachmentsResult struct {
+	ID          string                 `json:"id"`
+	Attachments []ConfluenceAttachment `json:"attachments"`
+}
+
+// ConfluenceDownloadAttachmentArgs parameters for downloading an attachment.
+type ConfluenceDownloadAttachmentArgs struct {
+	ID       string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	FileName string `json:"fileName" jsonschema:"required" jsonschema_description:"Attachment file name"`
+	MaxBytes int64  `json:"maxBytes,omitempty" jsonschema_description:"Size limit in bytes (default 1 MiB, max 10 MiB)" jsonschema:"minimum=1,maximum=10485760"`
+}
+
+func (c *ConfluenceTools) handleUploadAttachment(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceUploadAttachmentArgs) (*mcp.CallToolResult, error) {
+	data, err := base64.StdEncoding.DecodeString(args.Data)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("invalid base64 data", err), nil
+	}
+
+	attachment, err := c.service.UploadAttachment(ctx, args.ID, confluence.AttachmentInput{
+		FileName:  args.FileName,
+		Data:      data,
+		Comment:   args.Comment,
+		MinorEdit: args.MinorEdit,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence upload attachment failed", err), nil
+	}
+
+	result := c.toConfluenceAttachment(*attachment)
+	if result.FileName == "" {
+		result.FileName = args.FileName
+	}
+	if result.MediaType == "" {
+		result.MediaType = mime.TypeByExtension(path.Ext(args.FileName))
+	}
+	if strings.HasPrefix(result.MediaType, "image/") {
+		result.ImageMarkup = confluence.ImageMarkup(result.FileName, confluence.ImageOptions{})
+	}
+
+	fallback := fmt.Sprintf("Uploaded %s to page %s (version %d)", result.FileName, args.ID, result.Version)
+	if result.ImageMarkup != "" {
+		fallback += "\nEmbed with: " + result.ImageMarkup
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleListAttachments(ctx context.Context, _ mcp.CallToolRequest, args ConfluencePageIDArgs) (*mcp.CallToolResult, error) {
+	attachments, err := c.service.ListAttachments(ctx, args.ID, "")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence list attachments failed", err), nil
+	}
+
+	result := ConfluenceListAttachmentsResult{
+		ID:          args.ID,
+		Attachments: make([]ConfluenceAttachment, 0, len(attachments)),
+	}
+	for _, attachment := range attachments {
+		result.Attachments = append(result.Attachments, c.toConfluenceAttachment(attachment))
+	}
+
+	fallback := fmt.Sprintf("Found %d attachments on page %s", len(result.Attachments), args.ID)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleDownloadAttachment(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceDownloadAttachmentArgs) (*mcp.CallToolResult, error) {
+	if args.FileName == "" {
+		return mcp.NewToolResultError("fileName must not be empty"), nil
+	}
+
+	maxBytes := args.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultAttachmentBytes
+	}
+	if maxBytes > maxAttachmentBytes {
+		maxBytes = maxAttachmentBytes
+	}
+
+	attachments, err := c.service.ListAttachments(ctx, args.ID, args.FileName)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence download attachment failed", err), nil
+	}
+
+	var attachment *confluence.Attachment
+	for i := range attachments {
+		if attachments[i].Title == args.FileName {
+			attachment = &attachments[i]
+			break
+		}
+	}
+	if attachment == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("attachment %q not found on page %s", args.FileName, args.ID)), nil
+	}
+
+	data, err := c.service.DownloadAttachment(ctx, *attachment, maxBytes)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence download attachment failed", err), nil
+	}
+
+	mediaType := attachment.MediaType()
+	if mediaType == "" {
+		mediaType = mime.TypeByExtension(path.Ext(attachment.Title))
+	}
+	uri := c.baseURL + attachment.Links.Download
+
+	var resource mcp.ResourceContents
+	if isTextMediaType(mediaType) && utf8.Valid(data) {
+		resource = mcp.TextResourceContents{URI: uri, MIMEType: mediaType, Text: string(data)}
+	} else {
+		resource = mcp.BlobResourceContents{URI: uri, MIMEType: mediaType, Blob: base64.StdEncoding.EncodeToString(data)}
+	}
+
+	summary := fmt.Sprintf("Downloaded %s (%s, %d bytes)", attachment.Title, mediaType, len(data))
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(summary),
+			mcp.NewEmbeddedResource(resource),
+		},
+	}, nil
+}
+
+func (c *ConfluenceTools) toConfluenceAttachment(attachment confluence.Attachment) ConfluenceAttachment {
+	comment := attachment.Metadata.Comment
+	if comment == "" {
+		comment = attachment.Extensions.Comment
+	}
+
+	result := ConfluenceAttachment{
+		ID:        attachment.ID,
+		FileName:  attachment.Title,
+		MediaType: attachment.MediaType(),
+		FileSize:  attachment.Extensions.FileSize,
+		Version:   attachment.Version.Number,
+		Comment:   comment,
+	}
+	if attachment.Links.Download != "" {
+		result.DownloadURL = c.baseURL + attachment.Links.Download
+	}
+
+	return result
+}
+
+func isTextMediaType(mediaType string) bool {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/yaml", "application/x-yaml", "application/javascript", "image/svg+xml":
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
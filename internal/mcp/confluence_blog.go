@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerBlogTools registers blog post tools. Creating, updating and reading
+// blog posts goes through create_page, update_page and get_page.
+func (c *ConfluenceTools) registerBlogTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.list_blog_posts",
+			mcp.WithDescription("List blog posts in a Confluence space, newest first, optionally within a creation date range. Create or update blog posts with create_page/update_page and type blogpost"),
+			mcp.WithInputSchema[ConfluenceListBlogPostsArgs](),
+			mcp.WithOutputSchema[ConfluenceListBlogPostsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleListBlogPosts),
+	)
+}
+
+// ConfluenceListBlogPostsArgs parameters for listing blog posts.
+type ConfluenceListBlogPostsArgs struct {
+	SpaceKey string `json:"spaceKey" jsonschema:"required" jsonschema_description:"Space key"`
+	From     string `json:"from,omitempty" jsonschema_description:"Earliest creation date, inclusive (YYYY-MM-DD, RFC3339, or an age like 7d or 2w)"`
+	To       string `json:"to,omitempty" jsonschema_description:"Latest creation date, exclusive (same formats as from)"`
+	Limit    int    `json:"limit,omitempty" jsonschema_description:"Maximum blog posts to return (default 25)" jsonschema:"minimum=1,maximum=200"`
+}
+
+// ConfluenceBlogPost summarises a blog post.
+type ConfluenceBlogPost struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Author  string `json:"author,omitempty"`
+	Created string `json:"created,omitempty"`
+	Version int    `json:"version"`
+	URL     string `json:"url"`
+}
+
+// ConfluenceListBlogPostsResult lists blog posts.
+type ConfluenceListBlogPostsResult struct {
+	SpaceKey  string               `json:"spaceKey"`
+	BlogPosts []ConfluenceBlogPost `json:"blogPosts"`
+}
+
+func (c *ConfluenceTools) handleListBlogPosts(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceListBlogPostsArgs) (*mcp.CallToolResult, error) {
+	query := confluence.BlogPostQuery{SpaceKey: args.SpaceKey, Limit: args.Limit}
+
+	now := time.Now()
+	if args.From != "" {
+		from, err := parseSince(args.From, now)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid from", err), nil
+		}
+		query.From = from
+	}
+	if args.To != "" {
+		to, err := parseSince(args.To, now)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid to", err), nil
+		}
+		query.To = to
+	}
+
+	posts, err := c.service.ListBlogPosts(ctx, query)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence list blog posts failed", err), nil
+	}
+
+	result := ConfluenceListBlogPostsResult{
+		SpaceKey:  args.SpaceKey,
+		BlogPosts: make([]ConfluenceBlogPost, 0, len(posts)),
+	}
+	for _, post := range posts {
+		summary := ConfluenceBlogPost{
+			ID:      post.ID,
+			Title:   post.Title,
+			Version: post.Version.Number,
+			URL:     c.pageURL(post.ID),
+		}
+		if history := post.History; history != nil {
+			summary.Author = history.CreatedBy.DisplayName
+			if summary.Author == "" {
+				summary.Author = history.CreatedBy.Username
+			}
+			summary.Created = history.CreatedDate
+		}
+		result.BlogPosts = append(result.BlogPosts, summary)
+	}
+
+	return mcp.NewToolResultStructured(result, renderBlogPosts(result)), nil
+}
+
+func renderBlogPosts(result ConfluenceListBlogPostsResult) string {
+	if len(result.BlogPosts) == 0 {
+		return fmt.Sprintf("No blog posts found in space %s", result.SpaceKey)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d blog posts in space %s\n", len(result.BlogPosts), result.SpaceKey)
+	for _, post := range result.BlogPosts {
+		day, _, _ := strings.Cut(post.Created, "T")
+		fmt.Fprintf(&b, "- [%s] %s", post.ID, post.Title)
+		if day != "" {
+			fmt.Fprintf(&b, " (%s", day)
+			if post.Author != "" {
+				fmt.Fprintf(&b, ", %s", post.Author)
+			}
+			b.WriteString(")")
+		}
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
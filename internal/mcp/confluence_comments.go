@@ -0,0 +1,228 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerCommentTools registers page comment tools.
+func (c *ConfluenceTools) registerCommentTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.list_comments",
+			mcp.WithDescription("List a Confluence page's footer comments (threaded with replies) and inline comments with the text they highlight"),
+			mcp.WithInputSchema[ConfluenceListCommentsArgs](),
+			mcp.WithOutputSchema[ConfluenceListCommentsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleListComments),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.add_comment",
+			mcp.WithDescription("Add a footer comment to a Confluence page or blog post, or reply to an existing comment"),
+			mcp.WithInputSchema[ConfluenceAddCommentArgs](),
+			mcp.WithOutputSchema[ConfluenceComment](),
+		),
+		mcp.NewTypedToolHandler(c.handleAddComment),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.resolve_comment",
+			mcp.WithDescription("Resolve or reopen an inline comment"),
+			mcp.WithInputSchema[ConfluenceResolveCommentArgs](),
+			mcp.WithOutputSchema[ConfluenceComment](),
+		),
+		mcp.NewTypedToolHandler(c.handleResolveComment),
+	)
+}
+
+// ConfluenceListCommentsArgs parameters for listing comments.
+type ConfluenceListCommentsArgs struct {
+	ID              string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Location        string `json:"location,omitempty" jsonschema:"enum=footer,enum=inline,enum=all" jsonschema_description:"Comment location to list (default all)"`
+	IncludeResolved bool   `json:"includeResolved,omitempty" jsonschema_description:"Include resolved inline comments"`
+}
+
+// ConfluenceComment describes a comment. Depth is 0 for top-level comments.
+type ConfluenceComment struct {
+	ID         string `json:"id"`
+	ParentID   string `json:"parentId,omitempty"`
+	Depth      int    `json:"depth"`
+	Location   string `json:"location"`
+	Author     string `json:"author,omitempty"`
+	Created    string `json:"created,omitempty"`
+	Body       string `json:"body"`
+	Selection  string `json:"selection,omitempty"`
+	Resolution string `json:"resolution,omitempty"`
+	Version    int    `json:"version"`
+}
+
+// ConfluenceListCommentsResult lists comments in thread order.
+type ConfluenceListCommentsResult struct {
+	ID       string              `json:"id"`
+	Comments []ConfluenceComment `json:"comments"`
+}
+
+// ConfluenceAddCommentArgs parameters for adding a comment.
+type ConfluenceAddCommentArgs struct {
+	ID       string `json:"id" jsonschema:"required" jsonschema_description:"Page or blog post ID"`
+	Type     string `json:"type,omitempty" jsonschema:"enum=page,enum=blogpost" jsonschema_description:"Content type of id (default page)"`
+	Body     string `json:"body" jsonschema:"required" jsonschema_description:"Comment body in storage format"`
+	ParentID string `json:"parentId,omitempty" jsonschema_description:"Comment ID to reply to"`
+}
+
+// ConfluenceResolveCommentArgs parameters for resolving an inline comment.
+type ConfluenceResolveCommentArgs struct {
+	ID     string `json:"id" jsonschema:"required" jsonschema_description:"Inline comment ID"`
+	Reopen bool   `json:"reopen,omitempty" jsonschema_description:"Reopen instead of resolving"`
+}
+
+func (c *ConfluenceTools) handleListComments(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceListCommentsArgs) (*mcp.CallToolResult, error) {
+	location := args.Location
+	if location == "all" {
+		location = ""
+	}
+
+	comments, err := c.service.ListComments(ctx, args.ID, location)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence list comments failed", err), nil
+	}
+
+	result := ConfluenceListCommentsResult{
+		ID:       args.ID,
+		Comments: threadComments(comments, args.IncludeResolved),
+	}
+
+	return mcp.NewToolResultStructured(result, renderComments(result.Comments)), nil
+}
+
+func (c *ConfluenceTools) handleAddComment(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceAddCommentArgs) (*mcp.CallToolResult, error) {
+	created, err := c.service.AddComment(ctx, args.ID, args.Type, args.ParentID, args.Body)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence add comment failed", err), nil
+	}
+
+	result := toConfluenceComment(*created, 0)
+	result.ParentID = args.ParentID
+	if result.Location == "" {
+		result.Location = confluence.CommentFooter
+	}
+
+	container := "page"
+	if args.Type == confluence.TypeBlogPost {
+		container = "blog post"
+	}
+	fallback := fmt.Sprintf("Added comment %s to %s %s", created.ID, container, args.ID)
+	if args.ParentID != "" {
+		fallback = fmt.Sprintf("Replied to comment %s with comment %s", args.ParentID, created.ID)
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleResolveComment(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceResolveCommentArgs) (*mcp.CallToolResult, error) {
+	updated, err := c.service.ResolveComment(ctx, args.ID, !args.Reopen)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence resolve comment failed", err), nil
+	}
+
+	result := toConfluenceComment(*updated, 0)
+	action := "Resolved"
+	if args.Reopen {
+		action = "Reopened"
+	}
+
+	fallback := fmt.Sprintf("%s inline comment %s", action, args.ID)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// threadComments orders comments so that replies follow their parent, depth
+// first, and drops resolved inline threads unless includeResolved is set.
+func threadComments(comments []confluence.Comment, includeResolved bool) []ConfluenceComment {
+	known := make(map[string]bool, len(comments))
+	for _, comment := range comments {
+		known[comment.ID] = true
+	}
+
+	children := map[string][]confluence.Comment{}
+	var roots []confluence.Comment
+	for _, comment := range comments {
+		parent := comment.ParentID()
+		if parent == "" || !known[parent] {
+			roots = append(roots, comment)
+			continue
+		}
+		children[parent] = append(children[parent], comment)
+	}
+
+	out := make([]ConfluenceComment, 0, len(comments))
+	var walk func(comment confluence.Comment, depth int)
+	walk = func(comment confluence.Comment, depth int) {
+		out = append(out, toConfluenceComment(comment, depth))
+		for _, reply := range children[comment.ID] {
+			walk(reply, depth+1)
+		}
+	}
+	for _, root := range roots {
+		if !includeResolved && root.Extensions.Resolution != nil && root.Extensions.Resolution.Status == "resolved" {
+			continue
+		}
+		walk(root, 0)
+	}
+
+	return out
+}
+
+func toConfluenceComment(comment confluence.Comment, depth int) ConfluenceComment {
+	result := ConfluenceComment{
+		ID:       comment.ID,
+		ParentID: comment.ParentID(),
+		Depth:    depth,
+		Location: comment.Extensions.Location,
+		Body:     confluence.StorageToText(comment.Body.Storage.Value),
+		Version:  comment.Version.Number,
+	}
+	if history := comment.History; history != nil {
+		result.Author = history.CreatedBy.DisplayName
+		if result.Author == "" {
+			result.Author = history.CreatedBy.Username
+		}
+		result.Created = history.CreatedDate
+	}
+	if comment.Extensions.InlineProperties != nil {
+		result.Selection = comment.Extensions.InlineProperties.OriginalSelection
+	}
+	if comment.Extensions.Resolution != nil {
+		result.Resolution = comment.Extensions.Resolution.Status
+	}
+
+	return result
+}
+
+func renderComments(comments []ConfluenceComment) string {
+	if len(comments) == 0 {
+		return "No comments"
+	}
+
+	var b strings.Builder
+	for _, comment := range comments {
+		indent := strings.Repeat("  ", comment.Depth)
+		fmt.Fprintf(&b, "%s- [%s] %s", indent, comment.ID, comment.Author)
+		if comment.Location == confluence.CommentInline && comment.Depth == 0 {
+			fmt.Fprintf(&b, " on %q", comment.Selection)
+			if comment.Resolution != "" {
+				fmt.Fprintf(&b, " (%s)", comment.Resolution)
+			}
+		}
+		fmt.Fprintf(&b, ": %s\n", strings.ReplaceAll(comment.Body, "\n", " "))
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
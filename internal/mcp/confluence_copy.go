@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerCopyTools registers page copy tools.
+func (c *ConfluenceTools) registerCopyTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.copy_page",
+			mcp.WithDescription("Copy a Confluence page, optionally with all its descendants, under a target parent page or into another space. Titles get a prefix and/or suffix; labels and attachments can be copied too. Sends progress notifications when the request carries a progress token"),
+			mcp.WithInputSchema[ConfluenceCopyPageArgs](),
+			mcp.WithOutputSchema[ConfluenceCopyPageResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleCopyPage),
+	)
+}
+
+// ConfluenceCopyPageArgs parameters for copying a page.
+type ConfluenceCopyPageArgs struct {
+	ID                 string `json:"id" jsonschema:"required" jsonschema_description:"Page ID to copy"`
+	TargetParentID     string `json:"targetParentId,omitempty" jsonschema_description:"Page to copy under"`
+	SpaceKey           string `json:"spaceKey,omitempty" jsonschema_description:"Space to copy into, under its homepage, when targetParentId is omitted"`
+	TitlePrefix        string `json:"titlePrefix,omitempty" jsonschema_description:"Text prepended to every copied title"`
+	TitleSuffix        string `json:"titleSuffix,omitempty" jsonschema_description:"Text appended to every copied title; a prefix or suffix is required within the same space"`
+	IncludeDescendants bool   `json:"includeDescendants,omitempty" jsonschema_description:"Copy the whole subtree below the page"`
+	CopyLabels         bool   `json:"copyLabels,omitempty" jsonschema_description:"Copy page labels"`
+	CopyAttachments    bool   `json:"copyAttachments,omitempty" jsonschema_description:"Copy page attachments"`
+	Limit              int    `json:"limit,omitempty" jsonschema_description:"Maximum descendants to copy (default 200); the copy is refused if the subtree is larger" jsonschema:"minimum=1,maximum=1000"`
+}
+
+// ConfluenceCopiedPage maps a source page to its copy.
+type ConfluenceCopiedPage struct {
+	SourceID    string `json:"sourceId"`
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	ParentID    string `json:"parentId,omitempty"`
+	Attachments int    `json:"attachments,omitempty"`
+	URL         string `json:"url"`
+}
+
+// ConfluenceCopyPageResult lists the copied pages, parents before children.
+type ConfluenceCopyPageResult struct {
+	Pages []ConfluenceCopiedPage `json:"pages"`
+}
+
+func (c *ConfluenceTools) handleCopyPage(ctx context.Context, req mcp.CallToolRequest, args ConfluenceCopyPageArgs) (*mcp.CallToolResult, error) {
+	copies, err := c.service.CopyPage(ctx, args.ID, confluence.CopyOptions{
+		TargetParentID:     args.TargetParentID,
+		SpaceKey:           args.SpaceKey,
+		TitlePrefix:        args.TitlePrefix,
+		TitleSuffix:        args.TitleSuffix,
+		IncludeDescendants: args.IncludeDescendants,
+		CopyLabels:         args.CopyLabels,
+		CopyAttachments:    args.CopyAttachments,
+		Limit:              args.Limit,
+		Progress: func(done, total int, page confluence.CopiedPage) {
+			notifyProgress(ctx, req, done, total, fmt.Sprintf("Copied %s", page.Title))
+		},
+	})
+
+	result := ConfluenceCopyPageResult{Pages: make([]ConfluenceCopiedPage, 0, len(copies))}
+	for _, page := range copies {
+		result.Pages = append(result.Pages, ConfluenceCopiedPage{
+			SourceID:    page.SourceID,
+			ID:          page.ID,
+			Title:       page.Title,
+			ParentID:    page.ParentID,
+			Attachments: page.Attachments,
+			URL:         c.pageURL(page.ID),
+		})
+	}
+
+	if err != nil {
+		if len(copies) > 0 {
+			err = fmt.Errorf("%w (%d pages were copied before the failure: %s)", err, len(copies), copiedIDs(copies))
+		}
+		return mcp.NewToolResultErrorFromErr("confluence copy page failed", err), nil
+	}
+
+	fallback := fmt.Sprintf("Copied %d pages; new root %s (%s)", len(result.Pages), result.Pages[0].Title, result.Pages[0].URL)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func copiedIDs(copies []confluence.CopiedPage) string {
+	ids := make([]string, 0, len(copies))
+	for _, page := range copies {
+		ids = append(ids, page.ID)
+	}
+	return strings.Join(ids, ", ")
+}
+
+// notifyProgress sends a progress notification when the client asked for
+// progress on this request. Delivery failures are ignored.
+func notifyProgress(ctx context.Context, req mcp.CallToolRequest, done, total int, message string) {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return
+	}
+
+	_ = srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": req.Params.Meta.ProgressToken,
+		"progress":      done,
+		"total":         total,
+		"message":       message,
+	})
+}
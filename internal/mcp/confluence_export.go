@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ylchen07/atlassian-mcp/internal/export"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerExportTools registers the Markdown export tool.
+func (c *ConfluenceTools) registerExportTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.export",
+			mcp.WithDescription("Export a Confluence space or page subtree to a local directory of Markdown files with front-matter, rewriting links between exported pages to relative paths. Re-running only rewrites pages whose version changed and removes files of deleted pages. Sends progress notifications when the request carries a progress token"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithInputSchema[ConfluenceExportArgs](),
+			mcp.WithOutputSchema[ConfluenceExportResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleExport),
+	)
+}
+
+// ConfluenceExportArgs parameters for exporting pages.
+type ConfluenceExportArgs struct {
+	SpaceKey    string `json:"spaceKey,omitempty" jsonschema_description:"Space to export"`
+	RootID      string `json:"rootId,omitempty" jsonschema_description:"Page whose subtree to export, instead of a whole space"`
+	Dir         string `json:"dir" jsonschema:"required" jsonschema_description:"Output directory relative to the server's working directory; absolute paths and paths leaving it are rejected"`
+	Attachments bool   `json:"attachments,omitempty" jsonschema_description:"Download page attachments"`
+	Limit       int    `json:"limit,omitempty" jsonschema_description:"Maximum pages to export (default 1000); the export fails if there are more" jsonschema:"minimum=1"`
+}
+
+// ConfluenceExportedPage reports one exported page.
+type ConfluenceExportedPage struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Path        string `json:"path"`
+	Version     int    `json:"version"`
+	Status      string `json:"status"`
+	Attachments int    `json:"attachments,omitempty"`
+}
+
+// ConfluenceExportResult summarises an export.
+type ConfluenceExportResult struct {
+	Dir       string                   `json:"dir"`
+	Written   int                      `json:"written"`
+	Unchanged int                      `json:"unchanged"`
+	Removed   []string                 `json:"removed,omitempty"`
+	Pages     []ConfluenceExportedPage `json:"pages"`
+}
+
+func (c *ConfluenceTools) handleExport(ctx context.Context, req mcp.CallToolRequest, args ConfluenceExportArgs) (*mcp.CallToolResult, error) {
+	if (args.SpaceKey == "") == (args.RootID == "") {
+		return mcp.NewToolResultError("provide exactly one of spaceKey or rootId"), nil
+	}
+	// The model picks dir, and the export deletes files in it, so keep it
+	// below the server's working directory.
+	if !filepath.IsLocal(args.Dir) {
+		return mcp.NewToolResultError("dir must be a relative path inside the server's working directory"), nil
+	}
+
+	report, err := export.NewExporter(c.service).Export(ctx, export.Options{
+		SpaceKey:    args.SpaceKey,
+		RootID:      args.RootID,
+		Dir:         args.Dir,
+		Attachments: args.Attachments,
+		Limit:       args.Limit,
+		BaseURL:     c.baseURL,
+		Progress: func(done, total int, page export.Page) {
+			notifyProgress(ctx, req, done, total, fmt.Sprintf("Exported %s", page.Path))
+		},
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence export failed", err), nil
+	}
+
+	result := toConfluenceExportResult(report)
+	fallback := fmt.Sprintf("Exported %d pages to %s: %d written, %d unchanged, %d removed", len(result.Pages), result.Dir, result.Written, result.Unchanged, len(result.Removed))
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func toConfluenceExportResult(report *export.Report) ConfluenceExportResult {
+	result := ConfluenceExportResult{
+		Dir:       report.Dir,
+		Written:   report.Written,
+		Unchanged: report.Unchanged,
+		Removed:   report.Removed,
+		Pages:     make([]ConfluenceExportedPage, 0, len(report.Pages)),
+	}
+	for _, page := range report.Pages {
+		result.Pages = append(result.Pages, ConfluenceExportedPage{
+			ID:          page.ID,
+			Title:       page.Title,
+			Path:        page.Path,
+			Version:     page.Version,
+			Status:      page.Status,
+			Attachments: page.Attachments,
+		})
+	}
+	return result
+}
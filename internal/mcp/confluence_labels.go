@@ -0,0 +1,194 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerLabelTools registers label management tools.
+func (c *ConfluenceTools) registerLabelTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.list_labels",
+			mcp.WithDescription("List the labels on a Confluence page, or the most popular labels in a space"),
+			mcp.WithInputSchema[ConfluenceListLabelsArgs](),
+			mcp.WithOutputSchema[ConfluenceListLabelsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleListLabels),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.add_labels",
+			mcp.WithDescription("Add labels to a Confluence page"),
+			mcp.WithInputSchema[ConfluenceLabelsArgs](),
+			mcp.WithOutputSchema[ConfluenceListLabelsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleAddLabels),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.remove_labels",
+			mcp.WithDescription("Remove labels from a Confluence page"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithInputSchema[ConfluenceLabelsArgs](),
+			mcp.WithOutputSchema[ConfluenceListLabelsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleRemoveLabels),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.search_by_label",
+			mcp.WithDescription("Find Confluence pages carrying all of the given labels"),
+			mcp.WithInputSchema[ConfluenceSearchByLabelArgs](),
+			mcp.WithOutputSchema[ConfluenceSearchResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleSearchByLabel),
+	)
+}
+
+// ConfluenceListLabelsArgs parameters for listing labels.
+type ConfluenceListLabelsArgs struct {
+	ID       string `json:"id,omitempty" jsonschema_description:"Page ID whose labels to list"`
+	SpaceKey string `json:"spaceKey,omitempty" jsonschema_description:"Space key; lists the space's most popular labels when id is omitted"`
+	Limit    int    `json:"limit,omitempty" jsonschema_description:"Maximum popular labels to return (default 25)" jsonschema:"minimum=1,maximum=200"`
+}
+
+// ConfluenceLabelsArgs parameters for adding or removing labels.
+type ConfluenceLabelsArgs struct {
+	ID     string   `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Labels []string `json:"labels" jsonschema:"required" jsonschema_description:"Label names; stored lower-case with spaces replaced by hyphens"`
+}
+
+// ConfluenceLabel is a label, with a usage count for popular labels.
+type ConfluenceLabel struct {
+	Name  string `json:"name"`
+	Count int    `json:"count,omitempty"`
+}
+
+// ConfluenceListLabelsResult lists labels.
+type ConfluenceListLabelsResult struct {
+	ID       string            `json:"id,omitempty"`
+	SpaceKey string            `json:"spaceKey,omitempty"`
+	Labels   []ConfluenceLabel `json:"labels"`
+}
+
+// ConfluenceSearchByLabelArgs parameters for finding pages by label.
+type ConfluenceSearchByLabelArgs struct {
+	Labels   []string `json:"labels" jsonschema:"required" jsonschema_description:"Labels the pages must all carry"`
+	SpaceKey string   `json:"spaceKey,omitempty" jsonschema_description:"Restrict to a space"`
+	Limit    int      `json:"limit,omitempty" jsonschema_description:"Maximum pages to return" jsonschema:"minimum=1,maximum=100"`
+}
+
+func (c *ConfluenceTools) handleListLabels(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceListLabelsArgs) (*mcp.CallToolResult, error) {
+	if args.ID == "" && args.SpaceKey == "" {
+		return mcp.NewToolResultError("either id or spaceKey must be provided"), nil
+	}
+
+	if args.ID != "" {
+		labels, err := c.service.GetLabels(ctx, args.ID)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence list labels failed", err), nil
+		}
+		return c.labelsResult(args.ID, labels), nil
+	}
+
+	popular, err := c.service.PopularLabels(ctx, args.SpaceKey, args.Limit)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence list labels failed", err), nil
+	}
+
+	result := ConfluenceListLabelsResult{
+		SpaceKey: args.SpaceKey,
+		Labels:   make([]ConfluenceLabel, 0, len(popular)),
+	}
+	parts := make([]string, 0, len(popular))
+	for _, label := range popular {
+		result.Labels = append(result.Labels, ConfluenceLabel{Name: label.Name, Count: label.Count})
+		parts = append(parts, fmt.Sprintf("%s (%d)", label.Name, label.Count))
+	}
+
+	fallback := fmt.Sprintf("Popular labels in %s: %s", args.SpaceKey, strings.Join(parts, ", "))
+	if len(parts) == 0 {
+		fallback = fmt.Sprintf("No labels found in %s", args.SpaceKey)
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleAddLabels(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceLabelsArgs) (*mcp.CallToolResult, error) {
+	labels, err := c.service.AddLabels(ctx, args.ID, args.Labels)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence add labels failed", err), nil
+	}
+
+	return c.labelsResult(args.ID, labels), nil
+}
+
+func (c *ConfluenceTools) handleRemoveLabels(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceLabelsArgs) (*mcp.CallToolResult, error) {
+	if len(args.Labels) == 0 {
+		return mcp.NewToolResultError("labels must not be empty"), nil
+	}
+
+	for _, label := range args.Labels {
+		if err := c.service.RemoveLabel(ctx, args.ID, label); err != nil {
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("confluence remove label %q failed", label), err), nil
+		}
+	}
+
+	labels, err := c.service.GetLabels(ctx, args.ID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence list labels failed", err), nil
+	}
+
+	return c.labelsResult(args.ID, labels), nil
+}
+
+func (c *ConfluenceTools) handleSearchByLabel(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceSearchByLabelArgs) (*mcp.CallToolResult, error) {
+	results, err := c.service.SearchByLabel(ctx, args.Labels, args.SpaceKey, args.Limit)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence search by label failed", err), nil
+	}
+
+	result := ConfluenceSearchResult{
+		Results: make([]ConfluencePageSummary, 0, len(results)),
+	}
+	for _, content := range results {
+		result.Results = append(result.Results, ConfluencePageSummary{
+			ID:      content.ID,
+			Title:   content.Title,
+			Type:    content.Type,
+			Status:  content.Status,
+			Version: content.Version.Number,
+			URL:     c.pageURL(content.ID),
+		})
+	}
+
+	fallback := fmt.Sprintf("Found %d pages labelled %s", len(result.Results), strings.Join(args.Labels, ", "))
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) labelsResult(id string, labels []confluence.Label) *mcp.CallToolResult {
+	result := ConfluenceListLabelsResult{
+		ID:     id,
+		Labels: make([]ConfluenceLabel, 0, len(labels)),
+	}
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		result.Labels = append(result.Labels, ConfluenceLabel{Name: label.Name})
+		names = append(names, label.Name)
+	}
+
+	fallback := fmt.Sprintf("Page %s labels: %s", id, strings.Join(names, ", "))
+	if len(names) == 0 {
+		fallback = fmt.Sprintf("Page %s has no labels", id)
+	}
+	return mcp.NewToolResultStructured(result, fallback)
+}
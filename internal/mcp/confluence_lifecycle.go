@@ -0,0 +1,166 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerLifecycleTools registers tools that trash, restore and reorganise pages.
+func (c *ConfluenceTools) registerLifecycleTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.delete_page",
+			mcp.WithDescription("Move a Confluence page to the space trash; it can be restored until purged"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithInputSchema[ConfluencePageIDArgs](),
+			mcp.WithOutputSchema[ConfluencePageStatusResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleDeletePage),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.purge_page",
+			mcp.WithDescription("Permanently delete a page that is already in the trash. This cannot be undone"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithInputSchema[ConfluencePageIDArgs](),
+			mcp.WithOutputSchema[ConfluencePageStatusResult](),
+		),
+		mcp.NewTypedToolHandler(c.handlePurgePage),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.restore_page",
+			mcp.WithDescription("Restore a trashed Confluence page to its original location"),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithInputSchema[ConfluencePageIDArgs](),
+			mcp.WithOutputSchema[ConfluencePageStatusResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleRestorePage),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.move_page",
+			mcp.WithDescription("Move a Confluence page before, after or under another page, or under the homepage of another space"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithInputSchema[ConfluenceMovePageArgs](),
+			mcp.WithOutputSchema[ConfluenceMovePageResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleMovePage),
+	)
+}
+
+// ConfluencePageIDArgs identifies a single page.
+type ConfluencePageIDArgs struct {
+	ID string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+}
+
+// ConfluencePageStatusResult reports the outcome of a lifecycle operation.
+type ConfluencePageStatusResult struct {
+	ID     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status"`
+	URL    string `json:"url,omitempty"`
+}
+
+// ConfluenceMovePageArgs parameters for moving a page.
+type ConfluenceMovePageArgs struct {
+	ID       string `json:"id" jsonschema:"required" jsonschema_description:"Page ID to move"`
+	TargetID string `json:"targetId,omitempty" jsonschema_description:"Page to move relative to"`
+	SpaceKey string `json:"spaceKey,omitempty" jsonschema_description:"Destination space whose homepage the page is placed under; use instead of targetId"`
+	Position string `json:"position,omitempty" jsonschema_description:"Position relative to targetId: before, after or append (child, default)" jsonschema:"enum=before,enum=after,enum=append"`
+}
+
+// ConfluenceMovePageResult describes a page after a move.
+type ConfluenceMovePageResult struct {
+	ID        string              `json:"id"`
+	Title     string              `json:"title"`
+	SpaceKey  string              `json:"spaceKey,omitempty"`
+	Ancestors []ConfluencePageRef `json:"ancestors"`
+	URL       string              `json:"url"`
+}
+
+func (c *ConfluenceTools) handleDeletePage(ctx context.Context, _ mcp.CallToolRequest, args ConfluencePageIDArgs) (*mcp.CallToolResult, error) {
+	if err := c.service.DeletePage(ctx, args.ID); err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence delete page failed", err), nil
+	}
+
+	result := ConfluencePageStatusResult{ID: args.ID, Status: "trashed"}
+	fallback := fmt.Sprintf("Moved Confluence page %s to the trash", args.ID)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handlePurgePage(ctx context.Context, _ mcp.CallToolRequest, args ConfluencePageIDArgs) (*mcp.CallToolResult, error) {
+	if err := c.service.PurgePage(ctx, args.ID); err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence purge page failed", err), nil
+	}
+
+	result := ConfluencePageStatusResult{ID: args.ID, Status: "purged"}
+	fallback := fmt.Sprintf("Permanently deleted Confluence page %s", args.ID)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleRestorePage(ctx context.Context, _ mcp.CallToolRequest, args ConfluencePageIDArgs) (*mcp.CallToolResult, error) {
+	restored, err := c.service.RestorePage(ctx, args.ID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence restore page failed", err), nil
+	}
+
+	result := ConfluencePageStatusResult{
+		ID:     restored.ID,
+		Title:  restored.Title,
+		Status: "current",
+		URL:    c.pageURL(restored.ID),
+	}
+
+	fallback := fmt.Sprintf("Restored Confluence page %s", restored.Title)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleMovePage(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceMovePageArgs) (*mcp.CallToolResult, error) {
+	if args.TargetID == "" && args.SpaceKey == "" {
+		return mcp.NewToolResultError("either targetId or spaceKey must be provided"), nil
+	}
+	if args.TargetID != "" && args.SpaceKey != "" {
+		return mcp.NewToolResultError("provide either targetId or spaceKey, not both"), nil
+	}
+
+	moved, err := c.service.MovePage(ctx, args.ID, confluence.MoveInput{
+		TargetID: args.TargetID,
+		SpaceKey: args.SpaceKey,
+		Position: args.Position,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence move page failed", err), nil
+	}
+
+	result := ConfluenceMovePageResult{
+		ID:        moved.ID,
+		Title:     moved.Title,
+		Ancestors: []ConfluencePageRef{},
+		URL:       c.pageURL(moved.ID),
+	}
+	if moved.Space != nil {
+		result.SpaceKey = moved.Space.Key
+	}
+
+	titles := make([]string, 0, len(moved.Ancestors))
+	for _, ancestor := range moved.Ancestors {
+		result.Ancestors = append(result.Ancestors, ConfluencePageRef{ID: ancestor.ID, Title: ancestor.Title, URL: c.pageURL(ancestor.ID)})
+		titles = append(titles, ancestor.Title)
+	}
+
+	fallback := fmt.Sprintf("Moved Confluence page %s", moved.Title)
+	if len(titles) > 0 {
+		fallback += " under " + strings.Join(titles, " > ")
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
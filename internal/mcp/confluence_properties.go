@@ -0,0 +1,201 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerPropertyTools registers content property tools.
+func (c *ConfluenceTools) registerPropertyTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.get_properties",
+			mcp.WithDescription("List the content properties of a Confluence page, or get a single property by key"),
+			mcp.WithInputSchema[ConfluenceGetPropertiesArgs](),
+			mcp.WithOutputSchema[ConfluencePropertiesResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleGetProperties),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.set_property",
+			mcp.WithDescription("Create or update a content property on a Confluence page. The version is bumped automatically; pass expectedVersion to fail on concurrent writes"),
+			mcp.WithInputSchema[ConfluenceSetPropertyArgs](),
+			mcp.WithOutputSchema[ConfluenceProperty](),
+		),
+		mcp.NewTypedToolHandler(c.handleSetProperty),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.delete_property",
+			mcp.WithDescription("Delete a content property from a Confluence page"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithInputSchema[ConfluencePropertyKeyArgs](),
+		),
+		mcp.NewTypedToolHandler(c.handleDeleteProperty),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.stamp_page",
+			mcp.WithDescription("Stamp a generated Confluence page with provenance properties such as generated-by and source-commit, optionally adding a label to find stamped pages later"),
+			mcp.WithInputSchema[ConfluenceStampPageArgs](),
+			mcp.WithOutputSchema[ConfluencePropertiesResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleStampPage),
+	)
+}
+
+// ConfluenceGetPropertiesArgs parameters for reading properties.
+type ConfluenceGetPropertiesArgs struct {
+	ID  string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Key string `json:"key,omitempty" jsonschema_description:"Property key; omit to list all properties"`
+}
+
+// ConfluencePropertyKeyArgs identifies a property.
+type ConfluencePropertyKeyArgs struct {
+	ID  string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Key string `json:"key" jsonschema:"required" jsonschema_description:"Property key"`
+}
+
+// ConfluenceSetPropertyArgs parameters for writing a property.
+type ConfluenceSetPropertyArgs struct {
+	ID              string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Key             string `json:"key" jsonschema:"required" jsonschema_description:"Property key"`
+	Value           any    `json:"value" jsonschema:"required" jsonschema_description:"Property value (any JSON value)"`
+	ExpectedVersion int    `json:"expectedVersion,omitempty" jsonschema_description:"Property version the write is based on; fails if the property has changed since"`
+}
+
+// ConfluenceStampPageArgs parameters for stamping a page.
+type ConfluenceStampPageArgs struct {
+	ID     string            `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Stamps map[string]string `json:"stamps" jsonschema:"required" jsonschema_description:"Property keys and values, e.g. generated-by and source-commit"`
+	Label  string            `json:"label,omitempty" jsonschema_description:"Label to add so stamped pages can be found with search_by_label"`
+}
+
+// ConfluenceProperty is a content property.
+type ConfluenceProperty struct {
+	Key     string `json:"key"`
+	Value   any    `json:"value"`
+	Version int    `json:"version"`
+}
+
+// ConfluencePropertiesResult lists content properties.
+type ConfluencePropertiesResult struct {
+	ID         string               `json:"id"`
+	Properties []ConfluenceProperty `json:"properties"`
+}
+
+func (c *ConfluenceTools) handleGetProperties(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceGetPropertiesArgs) (*mcp.CallToolResult, error) {
+	var properties []confluence.ContentProperty
+	if args.Key != "" {
+		property, err := c.service.GetProperty(ctx, args.ID, args.Key)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence get properties failed", err), nil
+		}
+		properties = []confluence.ContentProperty{*property}
+	} else {
+		listed, err := c.service.ListProperties(ctx, args.ID)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence get properties failed", err), nil
+		}
+		properties = listed
+	}
+
+	result := toConfluenceProperties(args.ID, properties)
+	return mcp.NewToolResultStructured(result, renderProperties(result)), nil
+}
+
+func (c *ConfluenceTools) handleSetProperty(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceSetPropertyArgs) (*mcp.CallToolResult, error) {
+	if args.Value == nil {
+		return mcp.NewToolResultError("value must be provided"), nil
+	}
+
+	property, err := c.service.SetProperty(ctx, args.ID, args.Key, args.Value, args.ExpectedVersion)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence set property failed", err), nil
+	}
+
+	result := toConfluenceProperty(*property)
+	if result.Key == "" {
+		result.Key = args.Key
+	}
+
+	fallback := fmt.Sprintf("Set property %s on page %s (version %d)", result.Key, args.ID, result.Version)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleDeleteProperty(ctx context.Context, _ mcp.CallToolRequest, args ConfluencePropertyKeyArgs) (*mcp.CallToolResult, error) {
+	if err := c.service.DeleteProperty(ctx, args.ID, args.Key); err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence delete property failed", err), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Deleted property %s from page %s", args.Key, args.ID)), nil
+}
+
+func (c *ConfluenceTools) handleStampPage(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceStampPageArgs) (*mcp.CallToolResult, error) {
+	properties, err := c.service.StampPage(ctx, args.ID, args.Stamps)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence stamp page failed", err), nil
+	}
+
+	if args.Label != "" {
+		if _, err := c.service.AddLabels(ctx, args.ID, []string{args.Label}); err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence stamp page failed", err), nil
+		}
+	}
+
+	result := toConfluenceProperties(args.ID, properties)
+	fallback := fmt.Sprintf("Stamped page %s with %d properties", args.ID, len(properties))
+	if args.Label != "" {
+		fallback += fmt.Sprintf(" and label %s", args.Label)
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func toConfluenceProperties(id string, properties []confluence.ContentProperty) ConfluencePropertiesResult {
+	result := ConfluencePropertiesResult{ID: id, Properties: make([]ConfluenceProperty, 0, len(properties))}
+	for _, property := range properties {
+		result.Properties = append(result.Properties, toConfluenceProperty(property))
+	}
+	return result
+}
+
+func toConfluenceProperty(property confluence.ContentProperty) ConfluenceProperty {
+	var value any
+	if len(property.Value) > 0 {
+		if err := json.Unmarshal(property.Value, &value); err != nil {
+			value = string(property.Value)
+		}
+	}
+
+	return ConfluenceProperty{
+		Key:     property.Key,
+		Value:   value,
+		Version: property.Version.Number,
+	}
+}
+
+func renderProperties(result ConfluencePropertiesResult) string {
+	if len(result.Properties) == 0 {
+		return fmt.Sprintf("Page %s has no content properties", result.ID)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Content properties of page %s", result.ID)
+	for _, property := range result.Properties {
+		encoded, _ := json.Marshal(property.Value)
+		fmt.Fprintf(&b, "\n- %s (v%d): %s", property.Key, property.Version, encoded)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// pageResourceExpand is the expansion needed to render confluence://page/{id}.
+var pageResourceExpand = []string{"body.storage", "version", "space", "ancestors", "metadata.labels", "history.lastUpdated"}
+
+// registerResources exposes pages and spaces as Markdown resources.
+func (c *ConfluenceTools) registerResources(s *server.MCPServer) {
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			"confluence://page/{id}",
+			"Confluence page",
+			mcp.WithTemplateDescription("A Confluence page or blog post rendered as Markdown, with its location, labels and child pages"),
+			mcp.WithTemplateMIMEType(markdownMIMEType),
+		),
+		c.readPageResource,
+	)
+
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			"confluence://space/{key}",
+			"Confluence space",
+			mcp.WithTemplateDescription("A Confluence space with its description and top-level pages"),
+			mcp.WithTemplateMIMEType(markdownMIMEType),
+		),
+		c.readSpaceResource,
+	)
+}
+
+func (c *ConfluenceTools) readPageResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	id, err := resourceArg(req, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := c.service.GetPage(ctx, id, pageResourceExpand)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []confluence.Content
+	if page.Type != confluence.TypeBlogPost {
+		if children, err = c.service.GetChildren(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return markdownResource(req, c.renderPage(page, children)), nil
+}
+
+func (c *ConfluenceTools) readSpaceResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	key, err := resourceArg(req, "key")
+	if err != nil {
+		return nil, err
+	}
+
+	space, err := c.service.GetSpace(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var pages []confluence.Content
+	if space.Homepage != nil && space.Homepage.ID != "" {
+		if pages, err = c.service.GetChildren(ctx, space.Homepage.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return markdownResource(req, c.renderSpace(space, pages)), nil
+}
+
+func (c *ConfluenceTools) renderPage(page *confluence.Content, children []confluence.Content) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", page.Title)
+
+	spaceKey := ""
+	if page.Space != nil {
+		spaceKey = page.Space.Key
+		writeField(&b, "Space", fmt.Sprintf("%s (%s)", page.Space.Name, page.Space.Key))
+	}
+	if len(page.Ancestors) > 0 {
+		titles := make([]string, 0, len(page.Ancestors))
+		for _, ancestor := range page.Ancestors {
+			titles = append(titles, ancestor.Title)
+		}
+		writeField(&b, "Location", strings.Join(titles, " > "))
+	}
+	if page.Version.Number > 0 {
+		writeField(&b, "Version", fmt.Sprint(page.Version.Number))
+	}
+	if page.History != nil && page.History.LastUpdated != nil {
+		updated := page.History.LastUpdated
+		writeField(&b, "Last updated", strings.TrimSpace(updated.When+" by "+updated.By.DisplayName))
+	}
+	if page.Metadata != nil && len(page.Metadata.Labels.Results) > 0 {
+		names := make([]string, 0, len(page.Metadata.Labels.Results))
+		for _, label := range page.Metadata.Labels.Results {
+			names = append(names, label.Name)
+		}
+		writeField(&b, "Labels", strings.Join(names, ", "))
+	}
+	writeField(&b, "Link", c.pageURL(page.ID))
+
+	body := confluence.StorageToMarkdown(page.Body.Storage.Value, confluence.MarkdownLinks{
+		Page: func(space, title string) string {
+			if space == "" {
+				space = spaceKey
+			}
+			if space == "" {
+				return ""
+			}
+			return fmt.Sprintf("%s/display/%s/%s", c.baseURL, url.PathEscape(space), url.QueryEscape(title))
+		},
+		Attachment: func(fileName string) string {
+			return fmt.Sprintf("%s/download/attachments/%s/%s", c.baseURL, page.ID, url.PathEscape(fileName))
+		},
+	})
+	if body = strings.TrimSpace(body); body != "" {
+		fmt.Fprintf(&b, "\n%s\n", body)
+	}
+
+	writePageList(&b, "Child pages", children)
+	return b.String()
+}
+
+func (c *ConfluenceTools) renderSpace(space *confluence.Space, pages []confluence.Content) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s (%s)\n\n", space.Name, space.Key)
+
+	writeField(&b, "Type", space.Type)
+	writeField(&b, "Status", space.Status)
+	if space.Homepage != nil && space.Homepage.ID != "" {
+		writeField(&b, "Homepage", fmt.Sprintf("[%s](confluence://page/%s)", space.Homepage.Title, space.Homepage.ID))
+	}
+	writeField(&b, "Link", fmt.Sprintf("%s/display/%s", c.baseURL, url.PathEscape(space.Key)))
+
+	if description := strings.TrimSpace(space.Description.Plain.Value); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+
+	writePageList(&b, "Pages", pages)
+	return b.String()
+}
+
+// writePageList lists pages as links to their confluence://page resources.
+func writePageList(b *strings.Builder, heading string, pages []confluence.Content) {
+	if len(pages) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", heading)
+	for _, page := range pages {
+		fmt.Fprintf(b, "- [%s](confluence://page/%s)\n", page.Title, page.ID)
+	}
+}
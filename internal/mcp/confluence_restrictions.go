@@ -0,0 +1,208 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerRestrictionTools registers page restriction tools.
+func (c *ConfluenceTools) registerRestrictionTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.get_restrictions",
+			mcp.WithDescription("Show the users and groups a Confluence page's view and edit access is restricted to"),
+			mcp.WithInputSchema[ConfluencePageIDArgs](),
+			mcp.WithOutputSchema[ConfluenceRestrictionsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleGetRestrictions),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.update_restrictions",
+			mcp.WithDescription("Add or remove users and groups on a Confluence page's view or edit restrictions. Removing every entry lifts the restriction"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithInputSchema[ConfluenceUpdateRestrictionsArgs](),
+			mcp.WithOutputSchema[ConfluenceRestrictionsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleUpdateRestrictions),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.check_permission",
+			mcp.WithDescription("Check whether a user or group can view or edit a Confluence page"),
+			mcp.WithInputSchema[ConfluenceCheckPermissionArgs](),
+			mcp.WithOutputSchema[ConfluencePermissionResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleCheckPermission),
+	)
+}
+
+// ConfluenceUpdateRestrictionsArgs parameters for updating restrictions.
+type ConfluenceUpdateRestrictionsArgs struct {
+	ID           string   `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Operation    string   `json:"operation" jsonschema:"required,enum=view,enum=edit" jsonschema_description:"Restriction to change"`
+	AddUsers     []string `json:"addUsers,omitempty" jsonschema_description:"Users to allow (account IDs on Cloud, usernames on Data Center)"`
+	RemoveUsers  []string `json:"removeUsers,omitempty" jsonschema_description:"Users to remove from the restriction"`
+	AddGroups    []string `json:"addGroups,omitempty" jsonschema_description:"Group names to allow"`
+	RemoveGroups []string `json:"removeGroups,omitempty" jsonschema_description:"Group names to remove from the restriction"`
+}
+
+// ConfluenceCheckPermissionArgs parameters for checking a permission.
+type ConfluenceCheckPermissionArgs struct {
+	ID        string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	User      string `json:"user,omitempty" jsonschema_description:"User to check (account ID on Cloud, username on Data Center)"`
+	Group     string `json:"group,omitempty" jsonschema_description:"Group to check instead of a user"`
+	Operation string `json:"operation" jsonschema:"required,enum=view,enum=edit" jsonschema_description:"Operation to check"`
+}
+
+// ConfluenceRestrictionUser identifies a user in a restriction.
+type ConfluenceRestrictionUser struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// ConfluenceRestriction lists who an operation is restricted to.
+type ConfluenceRestriction struct {
+	Operation  string                      `json:"operation"`
+	Restricted bool                        `json:"restricted"`
+	Users      []ConfluenceRestrictionUser `json:"users"`
+	Groups     []string                    `json:"groups"`
+}
+
+// ConfluenceRestrictionsResult lists a page's restrictions.
+type ConfluenceRestrictionsResult struct {
+	ID           string                  `json:"id"`
+	Restrictions []ConfluenceRestriction `json:"restrictions"`
+}
+
+// ConfluencePermissionResult answers a permission check.
+type ConfluencePermissionResult struct {
+	ID        string `json:"id"`
+	Subject   string `json:"subject"`
+	Operation string `json:"operation"`
+	Allowed   bool   `json:"allowed"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func (c *ConfluenceTools) handleGetRestrictions(ctx context.Context, _ mcp.CallToolRequest, args ConfluencePageIDArgs) (*mcp.CallToolResult, error) {
+	restrictions, err := c.service.GetRestrictions(ctx, args.ID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence get restrictions failed", err), nil
+	}
+
+	result := toConfluenceRestrictions(args.ID, restrictions)
+	return mcp.NewToolResultStructured(result, renderRestrictions(result)), nil
+}
+
+func (c *ConfluenceTools) handleUpdateRestrictions(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceUpdateRestrictionsArgs) (*mcp.CallToolResult, error) {
+	if len(args.AddUsers)+len(args.RemoveUsers)+len(args.AddGroups)+len(args.RemoveGroups) == 0 {
+		return mcp.NewToolResultError("at least one of addUsers, removeUsers, addGroups or removeGroups must be provided"), nil
+	}
+
+	restrictions, err := c.service.UpdateRestrictions(ctx, args.ID, confluence.RestrictionChange{
+		Operation:    args.Operation,
+		AddUsers:     args.AddUsers,
+		RemoveUsers:  args.RemoveUsers,
+		AddGroups:    args.AddGroups,
+		RemoveGroups: args.RemoveGroups,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence update restrictions failed", err), nil
+	}
+
+	result := toConfluenceRestrictions(args.ID, restrictions)
+	return mcp.NewToolResultStructured(result, renderRestrictions(result)), nil
+}
+
+func (c *ConfluenceTools) handleCheckPermission(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceCheckPermissionArgs) (*mcp.CallToolResult, error) {
+	subjectType, subject := "user", args.User
+	switch {
+	case args.User != "" && args.Group != "":
+		return mcp.NewToolResultError("provide either user or group, not both"), nil
+	case args.Group != "":
+		subjectType, subject = "group", args.Group
+	case args.User == "":
+		return mcp.NewToolResultError("one of user or group must be provided"), nil
+	}
+
+	check, err := c.service.CheckPermission(ctx, args.ID, subjectType, subject, args.Operation)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence check permission failed", err), nil
+	}
+
+	result := ConfluencePermissionResult{
+		ID:        args.ID,
+		Subject:   subjectType + " " + subject,
+		Operation: args.Operation,
+		Allowed:   check.Allowed,
+		Reason:    check.Reason,
+	}
+
+	verdict := "can"
+	if !check.Allowed {
+		verdict = "cannot"
+	}
+	fallback := fmt.Sprintf("%s %s %s page %s", result.Subject, verdict, args.Operation, args.ID)
+	if check.Reason != "" {
+		fallback += " (" + check.Reason + ")"
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func toConfluenceRestrictions(id string, restrictions []confluence.Restriction) ConfluenceRestrictionsResult {
+	result := ConfluenceRestrictionsResult{ID: id, Restrictions: make([]ConfluenceRestriction, 0, len(restrictions))}
+	for _, r := range restrictions {
+		operation := "view"
+		if r.Operation == confluence.RestrictionEdit {
+			operation = "edit"
+		}
+
+		entry := ConfluenceRestriction{
+			Operation:  operation,
+			Restricted: len(r.Users) > 0 || len(r.Groups) > 0,
+			Users:      make([]ConfluenceRestrictionUser, 0, len(r.Users)),
+			Groups:     append([]string{}, r.Groups...),
+		}
+		for _, user := range r.Users {
+			entry.Users = append(entry.Users, ConfluenceRestrictionUser{ID: user.ID(), DisplayName: user.DisplayName})
+		}
+		result.Restrictions = append(result.Restrictions, entry)
+	}
+
+	return result
+}
+
+func renderRestrictions(result ConfluenceRestrictionsResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Restrictions on page %s", result.ID)
+	for _, r := range result.Restrictions {
+		fmt.Fprintf(&b, "\n- %s: ", r.Operation)
+		if !r.Restricted {
+			b.WriteString("unrestricted")
+			continue
+		}
+
+		var entries []string
+		for _, user := range r.Users {
+			name := user.ID
+			if user.DisplayName != "" {
+				name = user.DisplayName + " (" + user.ID + ")"
+			}
+			entries = append(entries, name)
+		}
+		for _, group := range r.Groups {
+			entries = append(entries, "group "+group)
+		}
+		b.WriteString(strings.Join(entries, ", "))
+	}
+
+	return b.String()
+}
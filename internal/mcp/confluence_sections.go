@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerSectionTools registers tools for localized page edits.
+func (c *ConfluenceTools) registerSectionTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.edit_section",
+			mcp.WithDescription("Edit part of a Confluence page without resending the whole body: append or prepend content (to the page or under a heading), replace the content under a heading, or insert after an anchor macro. The rest of the page is left untouched"),
+			mcp.WithInputSchema[ConfluenceEditSectionArgs](),
+			mcp.WithOutputSchema[ConfluencePageResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleEditSection),
+	)
+}
+
+// ConfluenceEditSectionArgs parameters for a section-level edit.
+type ConfluenceEditSectionArgs struct {
+	ID              string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Mode            string `json:"mode" jsonschema:"required,enum=append,enum=prepend,enum=replace,enum=insert_after_anchor" jsonschema_description:"append/prepend to the page or to the section under heading; replace the section under heading; insert_after_anchor"`
+	Heading         string `json:"heading,omitempty" jsonschema_description:"Heading text identifying the section (case-insensitive)"`
+	Anchor          string `json:"anchor,omitempty" jsonschema_description:"Anchor macro name for insert_after_anchor"`
+	Content         string `json:"content,omitempty" jsonschema_description:"Content in storage format; may be empty for replace to clear a section"`
+	ExpectedVersion int    `json:"expectedVersion,omitempty" jsonschema_description:"Fail with a conflict if the page is no longer at this version"`
+}
+
+func (c *ConfluenceTools) handleEditSection(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceEditSectionArgs) (*mcp.CallToolResult, error) {
+	updated, err := c.service.EditSection(ctx, args.ID, confluence.SectionEdit{
+		Mode:    args.Mode,
+		Heading: args.Heading,
+		Anchor:  args.Anchor,
+		Content: args.Content,
+	}, args.ExpectedVersion)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence edit section failed", err), nil
+	}
+
+	result := ConfluencePageResult{
+		ID:      updated.ID,
+		Title:   updated.Title,
+		Version: updated.Version.Number,
+		URL:     c.pageURL(updated.ID),
+	}
+
+	fallback := fmt.Sprintf("Updated Confluence page %s (%s) to version %d", updated.Title, args.Mode, updated.Version.Number)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerSpaceTools registers space administration tools.
+func (c *ConfluenceTools) registerSpaceTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.get_space",
+			mcp.WithDescription("Retrieve a Confluence space with its homepage, type, status and a permissions summary"),
+			mcp.WithInputSchema[ConfluenceSpaceKeyArgs](),
+			mcp.WithOutputSchema[ConfluenceSpaceDetail](),
+		),
+		mcp.NewTypedToolHandler(c.handleGetSpace),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.create_space",
+			mcp.WithDescription("Create a Confluence space, optionally private, with an initial homepage body"),
+			mcp.WithInputSchema[ConfluenceCreateSpaceArgs](),
+			mcp.WithOutputSchema[ConfluenceSpaceDetail](),
+		),
+		mcp.NewTypedToolHandler(c.handleCreateSpace),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.archive_space",
+			mcp.WithDescription("Archive a Confluence space"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithInputSchema[ConfluenceSpaceKeyArgs](),
+			mcp.WithOutputSchema[ConfluenceSpaceDetail](),
+		),
+		mcp.NewTypedToolHandler(c.handleArchiveSpace),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.unarchive_space",
+			mcp.WithDescription("Restore an archived Confluence space"),
+			mcp.WithInputSchema[ConfluenceSpaceKeyArgs](),
+			mcp.WithOutputSchema[ConfluenceSpaceDetail](),
+		),
+		mcp.NewTypedToolHandler(c.handleUnarchiveSpace),
+	)
+}
+
+// ConfluenceSpaceKeyArgs identifies a space.
+type ConfluenceSpaceKeyArgs struct {
+	Key string `json:"key" jsonschema:"required" jsonschema_description:"Space key"`
+}
+
+// ConfluenceCreateSpaceArgs parameters for creating a space.
+type ConfluenceCreateSpaceArgs struct {
+	Key          string `json:"key" jsonschema:"required" jsonschema_description:"Space key (letters and numbers)"`
+	Name         string `json:"name" jsonschema:"required" jsonschema_description:"Space name"`
+	Description  string `json:"description,omitempty" jsonschema_description:"Plain text description"`
+	HomepageBody string `json:"homepageBody,omitempty" jsonschema_description:"Homepage content in storage format"`
+	Private      bool   `json:"private,omitempty" jsonschema_description:"Create a space only the configured account can see"`
+}
+
+// ConfluenceSpacePermission summarises who holds a space permission.
+type ConfluenceSpacePermission struct {
+	Permission string   `json:"permission"`
+	Users      []string `json:"users,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Anonymous  bool     `json:"anonymous,omitempty"`
+}
+
+// ConfluenceSpaceDetail describes a space.
+type ConfluenceSpaceDetail struct {
+	ID          string                      `json:"id"`
+	Key         string                      `json:"key"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description,omitempty"`
+	Type        string                      `json:"type,omitempty"`
+	Status      string                      `json:"status,omitempty"`
+	HomepageID  string                      `json:"homepageId,omitempty"`
+	HomepageURL string                      `json:"homepageUrl,omitempty"`
+	Permissions []ConfluenceSpacePermission `json:"permissions,omitempty"`
+	URL         string                      `json:"url"`
+}
+
+func (c *ConfluenceTools) handleGetSpace(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceSpaceKeyArgs) (*mcp.CallToolResult, error) {
+	space, err := c.service.GetSpace(ctx, args.Key)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence get space failed", err), nil
+	}
+
+	result := c.toConfluenceSpaceDetail(*space)
+	return mcp.NewToolResultStructured(result, renderSpace(result)), nil
+}
+
+func (c *ConfluenceTools) handleCreateSpace(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceCreateSpaceArgs) (*mcp.CallToolResult, error) {
+	space, err := c.service.CreateSpace(ctx, confluence.SpaceInput{
+		Key:          args.Key,
+		Name:         args.Name,
+		Description:  args.Description,
+		HomepageBody: args.HomepageBody,
+		Private:      args.Private,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence create space failed", err), nil
+	}
+
+	result := c.toConfluenceSpaceDetail(*space)
+	fallback := fmt.Sprintf("Created Confluence space %s (%s)", result.Key, result.URL)
+	if result.HomepageID != "" {
+		fallback += fmt.Sprintf("; homepage %s", result.HomepageID)
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleArchiveSpace(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceSpaceKeyArgs) (*mcp.CallToolResult, error) {
+	return c.setSpaceArchived(ctx, args.Key, true)
+}
+
+func (c *ConfluenceTools) handleUnarchiveSpace(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceSpaceKeyArgs) (*mcp.CallToolResult, error) {
+	return c.setSpaceArchived(ctx, args.Key, false)
+}
+
+func (c *ConfluenceTools) setSpaceArchived(ctx context.Context, key string, archived bool) (*mcp.CallToolResult, error) {
+	space, err := c.service.ArchiveSpace(ctx, key, archived)
+	if err != nil {
+		action := "archive"
+		if !archived {
+			action = "unarchive"
+		}
+		return mcp.NewToolResultErrorFromErr("confluence "+action+" space failed", err), nil
+	}
+
+	result := c.toConfluenceSpaceDetail(*space)
+	if result.Key == "" {
+		result.Key = key
+		result.URL = fmt.Sprintf("%s/spaces/%s", c.baseURL, key)
+	}
+
+	fallback := fmt.Sprintf("Space %s is now %s", result.Key, result.Status)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) toConfluenceSpaceDetail(space confluence.Space) ConfluenceSpaceDetail {
+	result := ConfluenceSpaceDetail{
+		ID:          space.ID.String(),
+		Key:         space.Key,
+		Name:        space.Name,
+		Description: strings.TrimSpace(space.Description.Plain.Value),
+		Type:        space.Type,
+		Status:      space.Status,
+		URL:         fmt.Sprintf("%s/spaces/%s", c.baseURL, space.Key),
+	}
+	if space.Homepage != nil && space.Homepage.ID != "" {
+		result.HomepageID = space.Homepage.ID
+		result.HomepageURL = c.pageURL(space.Homepage.ID)
+	}
+	for _, summary := range confluence.SummarizePermissions(space.Permissions) {
+		result.Permissions = append(result.Permissions, ConfluenceSpacePermission{
+			Permission: summary.Permission,
+			Users:      summary.Users,
+			Groups:     summary.Groups,
+			Anonymous:  summary.Anonymous,
+		})
+	}
+
+	return result
+}
+
+func renderSpace(space ConfluenceSpaceDetail) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)", space.Name, space.Key)
+	if space.Type != "" || space.Status != "" {
+		fmt.Fprintf(&b, ", %s space, %s", space.Type, space.Status)
+	}
+	if space.Description != "" {
+		fmt.Fprintf(&b, "\n%s", space.Description)
+	}
+	if space.HomepageID != "" {
+		fmt.Fprintf(&b, "\nHomepage: %s", space.HomepageURL)
+	}
+	for _, permission := range space.Permissions {
+		var holders []string
+		holders = append(holders, permission.Users...)
+		for _, group := range permission.Groups {
+			holders = append(holders, "group "+group)
+		}
+		if permission.Anonymous {
+			holders = append(holders, "anonymous")
+		}
+		fmt.Fprintf(&b, "\n- %s: %s", permission.Permission, strings.Join(holders, ", "))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerTemplateTools registers page template tools.
+func (c *ConfluenceTools) registerTemplateTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.list_templates",
+			mcp.WithDescription("List Confluence page templates of a space together with the global templates"),
+			mcp.WithInputSchema[ConfluenceListTemplatesArgs](),
+			mcp.WithOutputSchema[ConfluenceListTemplatesResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleListTemplates),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.get_template",
+			mcp.WithDescription("Retrieve a Confluence template's storage body and the variables it declares"),
+			mcp.WithInputSchema[ConfluenceTemplateIDArgs](),
+			mcp.WithOutputSchema[ConfluenceTemplateDetail](),
+		),
+		mcp.NewTypedToolHandler(c.handleGetTemplate),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.create_from_template",
+			mcp.WithDescription("Create a Confluence page from a template, filling its variables; the template's labels are applied to the page"),
+			mcp.WithInputSchema[ConfluenceCreateFromTemplateArgs](),
+			mcp.WithOutputSchema[ConfluencePageResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleCreateFromTemplate),
+	)
+}
+
+// ConfluenceListTemplatesArgs parameters for listing templates.
+type ConfluenceListTemplatesArgs struct {
+	SpaceKey          string `json:"spaceKey,omitempty" jsonschema_description:"Space key; omit to list only global templates"`
+	IncludeBlueprints bool   `json:"includeBlueprints,omitempty" jsonschema_description:"Also list blueprints (these usually cannot be instantiated through the API)"`
+}
+
+// ConfluenceTemplate summarises a template.
+type ConfluenceTemplate struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Kind        string   `json:"kind"`
+	SpaceKey    string   `json:"spaceKey,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// ConfluenceListTemplatesResult lists templates.
+type ConfluenceListTemplatesResult struct {
+	Templates []ConfluenceTemplate `json:"templates"`
+}
+
+// ConfluenceTemplateIDArgs identifies a template.
+type ConfluenceTemplateIDArgs struct {
+	ID string `json:"id" jsonschema:"required" jsonschema_description:"Template ID"`
+}
+
+// ConfluenceTemplateVariable describes a template variable.
+type ConfluenceTemplateVariable struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options,omitempty"`
+}
+
+// ConfluenceTemplateDetail describes a template with its body.
+type ConfluenceTemplateDetail struct {
+	ConfluenceTemplate
+	Body      string                       `json:"body"`
+	Variables []ConfluenceTemplateVariable `json:"variables"`
+}
+
+// ConfluenceCreateFromTemplateArgs parameters for creating a page from a template.
+type ConfluenceCreateFromTemplateArgs struct {
+	TemplateID             string            `json:"templateId" jsonschema:"required" jsonschema_description:"Template ID"`
+	SpaceKey               string            `json:"spaceKey" jsonschema:"required" jsonschema_description:"Space key"`
+	Title                  string            `json:"title" jsonschema:"required" jsonschema_description:"Page title"`
+	ParentID               string            `json:"parentId,omitempty" jsonschema_description:"Ancestor page ID"`
+	Variables              map[string]string `json:"variables,omitempty" jsonschema_description:"Values for the template variables, by name; every variable used in the template needs a value"`
+	Labels                 []string          `json:"labels,omitempty" jsonschema_description:"Labels to apply in addition to the template's labels"`
+	CopyParentRestrictions bool              `json:"copyParentRestrictions,omitempty" jsonschema_description:"Apply the parent page's view and edit restrictions to the new page"`
+}
+
+func (c *ConfluenceTools) handleListTemplates(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceListTemplatesArgs) (*mcp.CallToolResult, error) {
+	kinds := []string{confluence.TemplatePage}
+	if args.IncludeBlueprints {
+		kinds = append(kinds, confluence.TemplateBlueprint)
+	}
+	scopes := []string{""}
+	if args.SpaceKey != "" {
+		scopes = []string{args.SpaceKey, ""}
+	}
+
+	result := ConfluenceListTemplatesResult{Templates: []ConfluenceTemplate{}}
+	for _, spaceKey := range scopes {
+		for _, kind := range kinds {
+			templates, err := c.service.ListTemplates(ctx, spaceKey, kind)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("confluence list templates failed", err), nil
+			}
+			for _, template := range templates {
+				summary := toConfluenceTemplate(template)
+				if summary.SpaceKey == "" {
+					summary.SpaceKey = spaceKey
+				}
+				if summary.Kind == "" {
+					summary.Kind = kind
+				}
+				result.Templates = append(result.Templates, summary)
+			}
+		}
+	}
+
+	return mcp.NewToolResultStructured(result, renderTemplates(result.Templates)), nil
+}
+
+func (c *ConfluenceTools) handleGetTemplate(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceTemplateIDArgs) (*mcp.CallToolResult, error) {
+	template, err := c.service.GetTemplate(ctx, args.ID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence get template failed", err), nil
+	}
+
+	variables, err := confluence.TemplateVariables(template.Body.Storage.Value)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence get template failed", err), nil
+	}
+
+	result := ConfluenceTemplateDetail{
+		ConfluenceTemplate: toConfluenceTemplate(*template),
+		Body:               template.Body.Storage.Value,
+		Variables:          make([]ConfluenceTemplateVariable, 0, len(variables)),
+	}
+	names := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		result.Variables = append(result.Variables, ConfluenceTemplateVariable{
+			Name:    variable.Name,
+			Type:    variable.Type,
+			Options: variable.Options,
+		})
+		names = append(names, variable.Name)
+	}
+
+	fallback := fmt.Sprintf("Retrieved template %s", template.Name)
+	if len(names) > 0 {
+		fallback += " (variables: " + strings.Join(names, ", ") + ")"
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleCreateFromTemplate(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceCreateFromTemplateArgs) (*mcp.CallToolResult, error) {
+	created, err := c.service.CreateFromTemplate(ctx, confluence.TemplateInput{
+		TemplateID:             args.TemplateID,
+		SpaceKey:               args.SpaceKey,
+		Title:                  args.Title,
+		ParentID:               args.ParentID,
+		Variables:              args.Variables,
+		Labels:                 args.Labels,
+		CopyParentRestrictions: args.CopyParentRestrictions,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence create from template failed", err), nil
+	}
+
+	result := ConfluencePageResult{
+		ID:      created.ID,
+		Title:   created.Title,
+		Version: created.Version.Number,
+		URL:     c.pageURL(created.ID),
+	}
+
+	fallback := fmt.Sprintf("Created Confluence page %s from template %s", created.Title, args.TemplateID)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func toConfluenceTemplate(template confluence.Template) ConfluenceTemplate {
+	result := ConfluenceTemplate{
+		ID:          template.TemplateID,
+		Name:        template.Name,
+		Description: template.Description,
+		Kind:        template.TemplateType,
+	}
+	if template.Space != nil {
+		result.SpaceKey = template.Space.Key
+	}
+	for _, label := range template.Labels {
+		result.Labels = append(result.Labels, label.Name)
+	}
+
+	return result
+}
+
+func renderTemplates(templates []ConfluenceTemplate) string {
+	if len(templates) == 0 {
+		return "No templates found"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d templates\n", len(templates))
+	for _, template := range templates {
+		scope := "global"
+		if template.SpaceKey != "" {
+			scope = template.SpaceKey
+		}
+		fmt.Fprintf(&b, "- [%s] %s (%s %s)", template.ID, template.Name, scope, template.Kind)
+		if template.Description != "" {
+			fmt.Fprintf(&b, ": %s", template.Description)
+		}
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerTreeTools registers page hierarchy navigation tools.
+func (c *ConfluenceTools) registerTreeTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.get_page_tree",
+			mcp.WithDescription("Show where a page lives: its ancestors and a depth-limited outline of descendants, or the page tree of a whole space"),
+			mcp.WithInputSchema[ConfluencePageTreeArgs](),
+			mcp.WithOutputSchema[ConfluencePageTreeResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleGetPageTree),
+	)
+}
+
+// ConfluencePageTreeArgs parameters for retrieving a page tree.
+type ConfluencePageTreeArgs struct {
+	ID       string `json:"id,omitempty" jsonschema_description:"Root page ID"`
+	SpaceKey string `json:"spaceKey,omitempty" jsonschema_description:"Space key; returns the tree from the space's top-level pages when id is omitted"`
+	Depth    int    `json:"depth,omitempty" jsonschema_description:"Levels of descendants to include (default 2)" jsonschema:"minimum=1,maximum=10"`
+	Limit    int    `json:"limit,omitempty" jsonschema_description:"Maximum pages to include (default 200)" jsonschema:"minimum=1,maximum=1000"`
+}
+
+// ConfluencePageRef identifies a page.
+type ConfluencePageRef struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// ConfluencePageTreeNode is a page within the tree. Depth is relative to the root (1 = child).
+type ConfluencePageTreeNode struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	ParentID string `json:"parentId,omitempty"`
+	Depth    int    `json:"depth"`
+	URL      string `json:"url"`
+}
+
+// ConfluencePageTreeResult describes a page's position and descendants.
+type ConfluencePageTreeResult struct {
+	Root      *ConfluencePageRef       `json:"root,omitempty"`
+	Ancestors []ConfluencePageRef      `json:"ancestors"`
+	Pages     []ConfluencePageTreeNode `json:"pages"`
+	Truncated bool                     `json:"truncated"`
+	Outline   string                   `json:"outline"`
+}
+
+func (c *ConfluenceTools) handleGetPageTree(ctx context.Context, _ mcp.CallToolRequest, args ConfluencePageTreeArgs) (*mcp.CallToolResult, error) {
+	if args.ID == "" && args.SpaceKey == "" {
+		return mcp.NewToolResultError("either id or spaceKey must be provided"), nil
+	}
+
+	depth := args.Depth
+	if depth == 0 {
+		depth = 2
+	}
+	limit := args.Limit
+	if limit == 0 {
+		limit = 200
+	}
+
+	result := ConfluencePageTreeResult{
+		Ancestors: []ConfluencePageRef{},
+		Pages:     []ConfluencePageTreeNode{},
+	}
+
+	if args.ID != "" {
+		root, err := c.service.GetPage(ctx, args.ID, []string{"ancestors", "version"})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence get page tree failed", err), nil
+		}
+		result.Root = &ConfluencePageRef{ID: root.ID, Title: root.Title, URL: c.pageURL(root.ID)}
+		for _, ancestor := range root.Ancestors {
+			result.Ancestors = append(result.Ancestors, ConfluencePageRef{ID: ancestor.ID, Title: ancestor.Title, URL: c.pageURL(ancestor.ID)})
+		}
+
+		nodes, truncated, err := c.service.GetDescendants(ctx, root.ID, confluence.DescendantOptions{MaxDepth: depth, Limit: limit})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence get page tree failed", err), nil
+		}
+		result.Truncated = truncated
+		c.appendTreeNodes(&result, nodes, 0)
+	} else {
+		roots, err := c.service.GetSpaceRootPages(ctx, args.SpaceKey)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence get page tree failed", err), nil
+		}
+
+		for _, root := range roots {
+			if len(result.Pages) >= limit {
+				result.Truncated = true
+				break
+			}
+			c.appendTreeNodes(&result, []confluence.PageNode{{Content: root, Depth: 1}}, 0)
+			if depth == 1 {
+				continue
+			}
+
+			nodes, truncated, err := c.service.GetDescendants(ctx, root.ID, confluence.DescendantOptions{
+				MaxDepth: depth - 1,
+				Limit:    limit - len(result.Pages),
+			})
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("confluence get page tree failed", err), nil
+			}
+			result.Truncated = result.Truncated || truncated
+			c.appendTreeNodes(&result, nodes, 1)
+		}
+	}
+
+	result.Outline = renderPageOutline(result)
+
+	fallback := result.Outline
+	if result.Truncated {
+		fallback += "\n(tree truncated; increase limit to see more)"
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) appendTreeNodes(result *ConfluencePageTreeResult, nodes []confluence.PageNode, depthOffset int) {
+	for _, node := range nodes {
+		result.Pages = append(result.Pages, ConfluencePageTreeNode{
+			ID:       node.ID,
+			Title:    node.Title,
+			ParentID: node.ParentID,
+			Depth:    node.Depth + depthOffset,
+			URL:      c.pageURL(node.ID),
+		})
+	}
+}
+
+func (c *ConfluenceTools) pageURL(id string) string {
+	return fmt.Sprintf("%s/pages/%s", c.baseURL, id)
+}
+
+// renderPageOutline renders the tree as a nested Markdown list. Pages are
+// expected in depth-first order.
+func renderPageOutline(tree ConfluencePageTreeResult) string {
+	var b strings.Builder
+
+	for _, ancestor := range tree.Ancestors {
+		fmt.Fprintf(&b, "%s > ", ancestor.Title)
+	}
+
+	indent := 0
+	if tree.Root != nil {
+		fmt.Fprintf(&b, "[%s](%s)\n", tree.Root.Title, tree.Root.URL)
+	} else {
+		indent = 1
+	}
+
+	for _, page := range tree.Pages {
+		fmt.Fprintf(&b, "%s- [%s](%s)\n", strings.Repeat("  ", page.Depth-indent), page.Title, page.URL)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
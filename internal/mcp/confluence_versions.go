@@ -0,0 +1,240 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerVersionTools registers page history tools.
+func (c *ConfluenceTools) registerVersionTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.list_versions",
+			mcp.WithDescription("List a Confluence page's version history, newest first"),
+			mcp.WithInputSchema[ConfluenceListVersionsArgs](),
+			mcp.WithOutputSchema[ConfluenceListVersionsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleListVersions),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.diff_versions",
+			mcp.WithDescription("Show a unified text diff between two versions of a Confluence page, or of everything changed since a date"),
+			mcp.WithInputSchema[ConfluenceDiffVersionsArgs](),
+			mcp.WithOutputSchema[ConfluenceDiffVersionsResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleDiffVersions),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"confluence.restore_version",
+			mcp.WithDescription("Restore an earlier version of a Confluence page as the new current version"),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithInputSchema[ConfluenceRestoreVersionArgs](),
+			mcp.WithOutputSchema[ConfluencePageResult](),
+		),
+		mcp.NewTypedToolHandler(c.handleRestoreVersion),
+	)
+}
+
+// ConfluenceListVersionsArgs parameters for listing page versions.
+type ConfluenceListVersionsArgs struct {
+	ID    string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Limit int    `json:"limit,omitempty" jsonschema_description:"Maximum versions to return (default 25)" jsonschema:"minimum=1,maximum=500"`
+}
+
+// ConfluenceVersionSummary describes one page version.
+type ConfluenceVersionSummary struct {
+	Number    int    `json:"number"`
+	When      string `json:"when"`
+	Author    string `json:"author,omitempty"`
+	Message   string `json:"message,omitempty"`
+	MinorEdit bool   `json:"minorEdit"`
+}
+
+// ConfluenceListVersionsResult lists page versions.
+type ConfluenceListVersionsResult struct {
+	ID       string                     `json:"id"`
+	Versions []ConfluenceVersionSummary `json:"versions"`
+}
+
+// ConfluenceDiffVersionsArgs parameters for diffing page versions.
+type ConfluenceDiffVersionsArgs struct {
+	ID    string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	From  int    `json:"from,omitempty" jsonschema_description:"Older version number (default: the version before 'to', or the version current at 'since')"`
+	To    int    `json:"to,omitempty" jsonschema_description:"Newer version number (default: current version)"`
+	Since string `json:"since,omitempty" jsonschema_description:"Diff against the version that was current at this time: RFC3339, YYYY-MM-DD, or a relative age such as 7d or 2w"`
+}
+
+// ConfluenceDiffVersionsResult contains a text diff between two versions.
+type ConfluenceDiffVersionsResult struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	From    int    `json:"from"`
+	To      int    `json:"to"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+	Diff    string `json:"diff"`
+}
+
+// ConfluenceRestoreVersionArgs parameters for restoring a page version.
+type ConfluenceRestoreVersionArgs struct {
+	ID      string `json:"id" jsonschema:"required" jsonschema_description:"Page ID"`
+	Version int    `json:"version" jsonschema:"required" jsonschema_description:"Version number to restore"`
+	Message string `json:"message,omitempty" jsonschema_description:"Version comment for the restored version"`
+}
+
+func (c *ConfluenceTools) handleListVersions(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceListVersionsArgs) (*mcp.CallToolResult, error) {
+	limit := args.Limit
+	if limit == 0 {
+		limit = 25
+	}
+
+	versions, err := c.service.ListVersions(ctx, args.ID, limit)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence list versions failed", err), nil
+	}
+
+	result := ConfluenceListVersionsResult{
+		ID:       args.ID,
+		Versions: make([]ConfluenceVersionSummary, 0, len(versions)),
+	}
+	for _, version := range versions {
+		result.Versions = append(result.Versions, toConfluenceVersionSummary(version))
+	}
+
+	fallback := fmt.Sprintf("Found %d versions of page %s", len(result.Versions), args.ID)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleDiffVersions(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceDiffVersionsArgs) (*mcp.CallToolResult, error) {
+	if args.ID == "" {
+		return mcp.NewToolResultError("page id must not be empty"), nil
+	}
+
+	to := args.To
+	if to == 0 {
+		current, err := c.service.GetPage(ctx, args.ID, []string{"version"})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence diff versions failed", err), nil
+		}
+		to = current.Version.Number
+	}
+
+	from := args.From
+	if from == 0 && args.Since != "" {
+		since, err := parseSince(args.Since, time.Now())
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid since", err), nil
+		}
+		versions, err := c.service.ListVersions(ctx, args.ID, 0)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence diff versions failed", err), nil
+		}
+		// Zero means the page was created after since: diff from empty.
+		if version := confluence.VersionAt(versions, since); version != nil {
+			from = version.Number
+		}
+	} else if from == 0 {
+		from = to - 1
+	}
+	if from > to {
+		from, to = to, from
+	}
+
+	newer, err := c.service.GetPageAtVersion(ctx, args.ID, to)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence diff versions failed", err), nil
+	}
+
+	var oldText string
+	if from > 0 {
+		older, err := c.service.GetPageAtVersion(ctx, args.ID, from)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("confluence diff versions failed", err), nil
+		}
+		oldText = confluence.StorageToText(older.Body.Storage.Value)
+	}
+	newText := confluence.StorageToText(newer.Body.Storage.Value)
+
+	result := ConfluenceDiffVersionsResult{
+		ID:    args.ID,
+		Title: newer.Title,
+		From:  from,
+		To:    to,
+		Diff:  confluence.UnifiedDiff(fmt.Sprintf("version %d", from), fmt.Sprintf("version %d", to), oldText, newText),
+	}
+	result.Added, result.Removed = confluence.DiffStats(oldText, newText)
+
+	fallback := result.Diff
+	if fallback == "" {
+		fallback = fmt.Sprintf("No text changes between versions %d and %d of %s", from, to, newer.Title)
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (c *ConfluenceTools) handleRestoreVersion(ctx context.Context, _ mcp.CallToolRequest, args ConfluenceRestoreVersionArgs) (*mcp.CallToolResult, error) {
+	restored, err := c.service.RestoreVersion(ctx, args.ID, args.Version, args.Message)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("confluence restore version failed", err), nil
+	}
+
+	result := ConfluencePageResult{
+		ID:      restored.ID,
+		Title:   restored.Title,
+		Version: restored.Version.Number,
+		URL:     c.pageURL(restored.ID),
+	}
+
+	fallback := fmt.Sprintf("Restored version %d of %s as version %d", args.Version, restored.Title, restored.Version.Number)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func toConfluenceVersionSummary(version confluence.PageVersion) ConfluenceVersionSummary {
+	author := version.By.DisplayName
+	if author == "" {
+		author = version.By.Username
+	}
+	return ConfluenceVersionSummary{
+		Number:    version.Number,
+		When:      version.When,
+		Author:    author,
+		Message:   version.Message,
+		MinorEdit: version.MinorEdit,
+	}
+}
+
+// parseSince accepts an RFC3339 timestamp, a YYYY-MM-DD date, or a relative
+// age in days or weeks (e.g. 7d, 2w) measured back from now.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if n := len(value); n > 1 {
+		if count, err := strconv.Atoi(value[:n-1]); err == nil && count >= 0 {
+			switch strings.ToLower(value[n-1:]) {
+			case "d":
+				return now.AddDate(0, 0, -count), nil
+			case "w":
+				return now.AddDate(0, 0, -7*count), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised time %q (use RFC3339, YYYY-MM-DD, or an age like 7d)", value)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/ylchen07/atlassian-mcp/internal/jira"
+	"github.com/ylchen07/atlassian-mcp/internal/query"
 	"github.com/ylchen07/atlassian-mcp/internal/state"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -41,7 +43,7 @@ func NewJiraTools(s *server.MCPServer, service *jira.Service, cache *state.Cache
 	s.AddTool(
 		mcp.NewTool(
 			"jira.search_issues",
-			mcp.WithDescription("Execute a JQL search and return matching issues"),
+			mcp.WithDescription("Execute a JQL search, or a search built from structured filters, and return matching issues"),
 			mcp.WithInputSchema[JiraSearchIssuesArgs](),
 			mcp.WithOutputSchema[JiraSearchIssuesResult](),
 		),
@@ -91,7 +93,7 @@ func NewJiraTools(s *server.MCPServer, service *jira.Service, cache *state.Cache
 	s.AddTool(
 		mcp.NewTool(
 			"jira.transition_issue",
-			mcp.WithDescription("Move an issue using a workflow transition"),
+			mcp.WithDescription("Move an issue using a workflow transition ID, transition name or target status name; reports required screen fields and multi-step paths"),
 			mcp.WithInputSchema[JiraTransitionIssueArgs](),
 			mcp.WithOutputSchema[OperationStatus](),
 		),
@@ -108,6 +110,20 @@ func NewJiraTools(s *server.MCPServer, service *jira.Service, cache *state.Cache
 		mcp.NewTypedToolHandler(jt.handleAddAttachment),
 	)
 
+	s.AddTool(
+		mcp.NewTool(
+			"jira.bulk_update",
+			mcp.WithDescription("Apply one operation (setField, addLabel, removeLabel, transition, assign, comment) to every issue matched by a JQL query; use dryRun to preview affected keys"),
+			mcp.WithInputSchema[JiraBulkUpdateArgs](),
+			mcp.WithOutputSchema[jira.BulkResult](),
+		),
+		mcp.NewTypedToolHandler(jt.handleBulkUpdate),
+	)
+
+	jt.registerVersionTools(s)
+	jt.registerHistoryTools(s)
+	jt.registerFilterTools(s)
+
 	return jt
 }
 
@@ -147,6 +163,15 @@ type JiraTransition struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"to"`
+	Fields []JiraTransitionField `json:"fields,omitempty"`
+}
+
+// JiraTransitionField describes a field on the transition screen.
+type JiraTransitionField struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowedValues,omitempty"`
 }
 
 // JiraTransitionsResult wraps transition responses.
@@ -157,7 +182,9 @@ type JiraTransitionsResult struct {
 // JiraTransitionIssueArgs parameters for executing a transition.
 type JiraTransitionIssueArgs struct {
 	Key          string         `json:"key" jsonschema:"required" jsonschema_description:"Issue key"`
-	TransitionID string         `json:"transitionId" jsonschema:"required" jsonschema_description:"Workflow transition ID"`
+	TransitionID string         `json:"transitionId,omitempty" jsonschema_description:"Workflow transition ID"`
+	To           string         `json:"to,omitempty" jsonschema_description:"Target status or transition name (case-insensitive), alternative to transitionId"`
+	Resolution   string         `json:"resolution,omitempty" jsonschema_description:"Resolution name, for transitions whose screen requires one"`
 	Fields       map[string]any `json:"fields,omitempty" jsonschema_description:"Optional field updates to apply"`
 }
 
@@ -197,10 +224,31 @@ func (j *JiraTools) handleListProjects(ctx context.Context, _ mcp.CallToolReques
 
 // JiraSearchIssuesArgs parameters for JQL searches.
 type JiraSearchIssuesArgs struct {
-	JQL        string   `json:"jql" jsonschema:"required" jsonschema_description:"JQL query string"`
-	MaxResults int      `json:"maxResults,omitempty" jsonschema_description:"Maximum number of issues to fetch" jsonschema:"minimum=1,maximum=100"`
-	StartAt    int      `json:"startAt,omitempty" jsonschema_description:"Pagination offset" jsonschema:"minimum=0"`
-	Fields     []string `json:"fields,omitempty" jsonschema_description:"Additional fields to include"`
+	JQL        string             `json:"jql,omitempty" jsonschema_description:"JQL query string; required unless filters is given"`
+	Filters    *JiraSearchFilters `json:"filters,omitempty" jsonschema_description:"Structured filters compiled to JQL, as an alternative to jql"`
+	MaxResults int                `json:"maxResults,omitempty" jsonschema_description:"Maximum number of issues to fetch" jsonschema:"minimum=1,maximum=100"`
+	StartAt    int                `json:"startAt,omitempty" jsonschema_description:"Pagination offset" jsonschema:"minimum=0"`
+	Fields     []string           `json:"fields,omitempty" jsonschema_description:"Additional fields to include"`
+	Expand     []string           `json:"expand,omitempty" jsonschema_description:"Issue expansions (e.g., changelog)"`
+}
+
+// JiraSearchFilters are structured issue search filters.
+type JiraSearchFilters struct {
+	Projects      []string `json:"projects,omitempty" jsonschema_description:"Project keys"`
+	Types         []string `json:"types,omitempty" jsonschema_description:"Issue type names"`
+	Statuses      []string `json:"statuses,omitempty" jsonschema_description:"Status names"`
+	Priorities    []string `json:"priorities,omitempty" jsonschema_description:"Priority names"`
+	Labels        []string `json:"labels,omitempty" jsonschema_description:"Labels the issue must all carry"`
+	Assignee      string   `json:"assignee,omitempty" jsonschema_description:"Assignee account ID or username, me for the configured account, or unassigned"`
+	Reporter      string   `json:"reporter,omitempty" jsonschema_description:"Reporter account ID or username, or me"`
+	Text          string   `json:"text,omitempty" jsonschema_description:"Full-text search terms, matched literally"`
+	Sprint        string   `json:"sprint,omitempty" jsonschema_description:"open, closed, a sprint ID or a sprint name"`
+	Unresolved    bool     `json:"unresolved,omitempty" jsonschema_description:"Only issues without a resolution"`
+	CreatedAfter  string   `json:"createdAfter,omitempty" jsonschema_description:"YYYY-MM-DD, an offset such as -7d, or a function such as startOfWeek()"`
+	CreatedBefore string   `json:"createdBefore,omitempty" jsonschema_description:"Exclusive upper bound, same formats as createdAfter"`
+	UpdatedAfter  string   `json:"updatedAfter,omitempty" jsonschema_description:"Updated on or after, same formats as createdAfter"`
+	UpdatedBefore string   `json:"updatedBefore,omitempty" jsonschema_description:"Updated before, same formats as createdAfter"`
+	OrderBy       string   `json:"orderBy,omitempty" jsonschema_description:"Sort order, e.g. updated DESC"`
 }
 
 // JiraIssueSummary summarises issue details.
@@ -212,10 +260,13 @@ type JiraIssueSummary struct {
 	Assignee    string `json:"assignee,omitempty"`
 	Description any    `json:"description,omitempty"`
 	URL         string `json:"url"`
+
+	Changelog []jira.ChangelogEntry `json:"changelog,omitempty"`
 }
 
 // JiraSearchIssuesResult response payload.
 type JiraSearchIssuesResult struct {
+	JQL       string             `json:"jql,omitempty"`
 	Total     int                `json:"total"`
 	StartAt   int                `json:"startAt"`
 	MaxResult int                `json:"maxResults"`
@@ -223,15 +274,45 @@ type JiraSearchIssuesResult struct {
 }
 
 func (j *JiraTools) handleSearchIssues(ctx context.Context, _ mcp.CallToolRequest, args JiraSearchIssuesArgs) (*mcp.CallToolResult, error) {
-	if strings.TrimSpace(args.JQL) == "" {
+	jql := args.JQL
+	if args.Filters != nil {
+		if strings.TrimSpace(jql) != "" {
+			return mcp.NewToolResultError("provide either jql or filters, not both"), nil
+		}
+
+		f := args.Filters
+		built, err := query.BuildJQL(query.JQLFilter{
+			Projects:      f.Projects,
+			Types:         f.Types,
+			Statuses:      f.Statuses,
+			Priorities:    f.Priorities,
+			Labels:        f.Labels,
+			Assignee:      f.Assignee,
+			Reporter:      f.Reporter,
+			Text:          f.Text,
+			Sprint:        f.Sprint,
+			Unresolved:    f.Unresolved,
+			CreatedAfter:  f.CreatedAfter,
+			CreatedBefore: f.CreatedBefore,
+			UpdatedAfter:  f.UpdatedAfter,
+			UpdatedBefore: f.UpdatedBefore,
+			OrderBy:       f.OrderBy,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid search filters", err), nil
+		}
+		jql = built
+	}
+	if strings.TrimSpace(jql) == "" {
 		return mcp.NewToolResultError("JQL query must not be empty"), nil
 	}
 
 	req := jira.SearchRequest{
-		JQL:        args.JQL,
+		JQL:        jql,
 		StartAt:    args.StartAt,
 		MaxResults: args.MaxResults,
 		Fields:     args.Fields,
+		Expand:     args.Expand,
 	}
 
 	result, err := j.service.SearchIssues(ctx, req)
@@ -240,6 +321,7 @@ func (j *JiraTools) handleSearchIssues(ctx context.Context, _ mcp.CallToolReques
 	}
 
 	response := JiraSearchIssuesResult{
+		JQL:       jql,
 		Total:     result.Total,
 		StartAt:   result.StartAt,
 		MaxResult: result.MaxResult,
@@ -258,10 +340,13 @@ func (j *JiraTools) handleSearchIssues(ctx context.Context, _ mcp.CallToolReques
 		if issue.Fields.Assignee.DisplayName != "" {
 			summary.Assignee = issue.Fields.Assignee.DisplayName
 		}
+		if issue.Changelog != nil {
+			summary.Changelog = issue.Changelog.Histories
+		}
 		response.Issues = append(response.Issues, summary)
 	}
 
-	j.cache.SetLastJQL(args.JQL)
+	j.cache.SetLastJQL(jql)
 
 	fallback := fmt.Sprintf("Found %d/%d issues for JQL", len(response.Issues), response.Total)
 	return mcp.NewToolResultStructured(response, fallback), nil
@@ -363,9 +448,10 @@ func (j *JiraTools) handleListTransitions(ctx context.Context, _ mcp.CallToolReq
 	result := JiraTransitionsResult{Transitions: make([]JiraTransition, 0, len(transitions))}
 	for _, tr := range transitions {
 		result.Transitions = append(result.Transitions, JiraTransition{
-			ID:   tr.ID,
-			Name: tr.Name,
-			To:   tr.To,
+			ID:     tr.ID,
+			Name:   tr.Name,
+			To:     tr.To,
+			Fields: toJiraTransitionFields(tr.Fields),
 		})
 	}
 
@@ -374,14 +460,58 @@ func (j *JiraTools) handleListTransitions(ctx context.Context, _ mcp.CallToolReq
 }
 
 func (j *JiraTools) handleTransitionIssue(ctx context.Context, _ mcp.CallToolRequest, args JiraTransitionIssueArgs) (*mcp.CallToolResult, error) {
-	if err := j.service.TransitionIssue(ctx, args.Key, args.TransitionID, args.Fields); err != nil {
+	fields := args.Fields
+	if args.Resolution != "" {
+		if fields == nil {
+			fields = map[string]any{}
+		}
+		fields["resolution"] = map[string]string{"name": args.Resolution}
+	}
+
+	if args.TransitionID != "" {
+		if err := j.service.TransitionIssue(ctx, args.Key, args.TransitionID, fields); err != nil {
+			return mcp.NewToolResultErrorFromErr("jira transition issue failed", err), nil
+		}
+
+		fallback := fmt.Sprintf("Transitioned %s using %s", args.Key, args.TransitionID)
+		return mcp.NewToolResultStructured(OperationStatus{Message: fallback}, fallback), nil
+	}
+
+	if strings.TrimSpace(args.To) == "" {
+		return mcp.NewToolResultError("either transitionId or to must be provided"), nil
+	}
+
+	tr, err := j.service.TransitionIssueTo(ctx, args.Key, args.To, fields)
+	if err != nil {
 		return mcp.NewToolResultErrorFromErr("jira transition issue failed", err), nil
 	}
 
-	fallback := fmt.Sprintf("Transitioned %s using %s", args.Key, args.TransitionID)
+	fallback := fmt.Sprintf("Transitioned %s using %s to %s", args.Key, tr.Name, tr.To.Name)
 	return mcp.NewToolResultStructured(OperationStatus{Message: fallback}, fallback), nil
 }
 
+func toJiraTransitionFields(fields map[string]jira.TransitionField) []JiraTransitionField {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	out := make([]JiraTransitionField, 0, len(fields))
+	for id, field := range fields {
+		entry := JiraTransitionField{
+			ID:       id,
+			Name:     field.Name,
+			Required: field.Required && !field.HasDefaultValue,
+		}
+		for _, v := range field.AllowedValues {
+			entry.AllowedValues = append(entry.AllowedValues, v.Label())
+		}
+		out = append(out, entry)
+	}
+
+	sort.Slice(out, func(a, b int) bool { return out[a].ID < out[b].ID })
+	return out
+}
+
 func (j *JiraTools) handleAddAttachment(ctx context.Context, _ mcp.CallToolRequest, args JiraAddAttachmentArgs) (*mcp.CallToolResult, error) {
 	data, err := base64.StdEncoding.DecodeString(args.Data)
 	if err != nil {
@@ -395,3 +525,55 @@ func (j *JiraTools) handleAddAttachment(ctx context.Context, _ mcp.CallToolReque
 	fallback := fmt.Sprintf("Uploaded attachment %s to %s", args.FileName, args.Key)
 	return mcp.NewToolResultStructured(OperationStatus{Message: fallback}, fallback), nil
 }
+
+// JiraBulkUpdateArgs parameters for bulk operations on JQL-selected issues.
+type JiraBulkUpdateArgs struct {
+	JQL         string         `json:"jql" jsonschema:"required" jsonschema_description:"JQL selecting the issues to change"`
+	Operation   string         `json:"operation" jsonschema:"required,enum=setField,enum=addLabel,enum=removeLabel,enum=transition,enum=assign,enum=comment" jsonschema_description:"Operation to apply to each issue"`
+	Field       string         `json:"field,omitempty" jsonschema_description:"Field ID for setField"`
+	Value       any            `json:"value,omitempty" jsonschema_description:"Field value for setField"`
+	Label       string         `json:"label,omitempty" jsonschema_description:"Label for addLabel/removeLabel"`
+	Transition  string         `json:"transition,omitempty" jsonschema_description:"Target status, transition name or ID for transition"`
+	Fields      map[string]any `json:"fields,omitempty" jsonschema_description:"Transition screen fields for transition"`
+	AccountID   string         `json:"accountId,omitempty" jsonschema_description:"Assignee account ID for assign; empty unassigns"`
+	Comment     any            `json:"comment,omitempty" jsonschema_description:"Comment body for comment"`
+	DryRun      bool           `json:"dryRun,omitempty" jsonschema_description:"Only list affected issue keys without changing anything"`
+	MaxIssues   int            `json:"maxIssues,omitempty" jsonschema_description:"Maximum number of issues to process" jsonschema:"minimum=1,maximum=1000"`
+	Concurrency int            `json:"concurrency,omitempty" jsonschema_description:"Parallel requests" jsonschema:"minimum=1,maximum=10"`
+}
+
+func (j *JiraTools) handleBulkUpdate(ctx context.Context, _ mcp.CallToolRequest, args JiraBulkUpdateArgs) (*mcp.CallToolResult, error) {
+	if strings.TrimSpace(args.JQL) == "" {
+		return mcp.NewToolResultError("JQL query must not be empty"), nil
+	}
+
+	result, err := j.service.BulkUpdate(ctx, jira.BulkRequest{
+		JQL: args.JQL,
+		Operation: jira.BulkOperation{
+			Type:       args.Operation,
+			Field:      args.Field,
+			Value:      args.Value,
+			Label:      args.Label,
+			Transition: args.Transition,
+			Fields:     args.Fields,
+			AccountID:  args.AccountID,
+			Comment:    args.Comment,
+		},
+		DryRun:      args.DryRun,
+		MaxIssues:   args.MaxIssues,
+		Concurrency: args.Concurrency,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira bulk update failed", err), nil
+	}
+
+	j.cache.SetLastJQL(args.JQL)
+
+	var fallback string
+	if result.DryRun {
+		fallback = fmt.Sprintf("Dry run: %s would affect %d of %d issues", args.Operation, len(result.Results), result.Total)
+	} else {
+		fallback = fmt.Sprintf("Applied %s to %d issues: %d succeeded, %d failed", args.Operation, len(result.Results), result.Succeeded, result.Failed)
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
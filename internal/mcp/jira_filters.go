@@ -0,0 +1,266 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/jira"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerFilterTools registers saved filter and JQL assistance tools.
+func (j *JiraTools) registerFilterTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"jira.list_filters",
+			mcp.WithDescription("List saved Jira filters, optionally matching a name"),
+			mcp.WithInputSchema[JiraListFiltersArgs](),
+			mcp.WithOutputSchema[JiraFiltersResult](),
+		),
+		mcp.NewTypedToolHandler(j.handleListFilters),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.get_filter",
+			mcp.WithDescription("Retrieve a saved Jira filter and its JQL"),
+			mcp.WithInputSchema[JiraGetFilterArgs](),
+			mcp.WithOutputSchema[JiraFilter](),
+		),
+		mcp.NewTypedToolHandler(j.handleGetFilter),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.create_filter",
+			mcp.WithDescription("Save a JQL query as a Jira filter; defaults to the last executed search"),
+			mcp.WithInputSchema[JiraCreateFilterArgs](),
+			mcp.WithOutputSchema[JiraFilter](),
+		),
+		mcp.NewTypedToolHandler(j.handleCreateFilter),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.validate_jql",
+			mcp.WithDescription("Parse JQL without running it and report errors with line and column positions"),
+			mcp.WithInputSchema[JiraValidateJQLArgs](),
+			mcp.WithOutputSchema[JiraValidateJQLResult](),
+		),
+		mcp.NewTypedToolHandler(j.handleValidateJQL),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.jql_suggest",
+			mcp.WithDescription("Suggest JQL field names, or values for a field, matching a prefix"),
+			mcp.WithInputSchema[JiraJQLSuggestArgs](),
+			mcp.WithOutputSchema[JiraJQLSuggestResult](),
+		),
+		mcp.NewTypedToolHandler(j.handleJQLSuggest),
+	)
+}
+
+// JiraListFiltersArgs parameters for listing filters.
+type JiraListFiltersArgs struct {
+	Name       string `json:"name,omitempty" jsonschema_description:"Filter name to search for"`
+	MaxResults int    `json:"maxResults,omitempty" jsonschema_description:"Maximum number of filters to return" jsonschema:"minimum=1,maximum=100"`
+}
+
+// JiraFilter describes a saved filter.
+type JiraFilter struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	JQL         string `json:"jql"`
+	Owner       string `json:"owner,omitempty"`
+	Favourite   bool   `json:"favourite"`
+	URL         string `json:"url"`
+}
+
+// JiraFiltersResult wraps the filter list response.
+type JiraFiltersResult struct {
+	Filters []JiraFilter `json:"filters"`
+}
+
+// JiraGetFilterArgs parameters for retrieving a filter.
+type JiraGetFilterArgs struct {
+	ID string `json:"id" jsonschema:"required" jsonschema_description:"Filter ID"`
+}
+
+// JiraCreateFilterArgs parameters for saving a filter.
+type JiraCreateFilterArgs struct {
+	Name        string `json:"name" jsonschema:"required" jsonschema_description:"Filter name"`
+	JQL         string `json:"jql,omitempty" jsonschema_description:"JQL to save; defaults to the last executed search"`
+	Description string `json:"description,omitempty" jsonschema_description:"Filter description"`
+	Favourite   bool   `json:"favourite,omitempty" jsonschema_description:"Mark the filter as a favourite"`
+}
+
+// JiraValidateJQLArgs parameters for validating JQL.
+type JiraValidateJQLArgs struct {
+	JQL string `json:"jql,omitempty" jsonschema_description:"JQL to validate; defaults to the last executed search"`
+}
+
+// JiraValidateJQLResult reports JQL validation results.
+type JiraValidateJQLResult struct {
+	jira.JQLValidation
+}
+
+// JiraJQLSuggestArgs parameters for JQL autocomplete.
+type JiraJQLSuggestArgs struct {
+	FieldName string `json:"fieldName,omitempty" jsonschema_description:"Field to suggest values for; omit to suggest field names"`
+	Prefix    string `json:"prefix,omitempty" jsonschema_description:"Partially typed field name or value"`
+	Limit     int    `json:"limit,omitempty" jsonschema_description:"Maximum suggestions to return" jsonschema:"minimum=1,maximum=100"`
+}
+
+// JiraJQLSuggestion is a single autocomplete suggestion.
+type JiraJQLSuggestion struct {
+	Value       string   `json:"value"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Operators   []string `json:"operators,omitempty"`
+}
+
+// JiraJQLSuggestResult wraps autocomplete suggestions.
+type JiraJQLSuggestResult struct {
+	Kind        string              `json:"kind"`
+	Suggestions []JiraJQLSuggestion `json:"suggestions"`
+}
+
+func (j *JiraTools) handleListFilters(ctx context.Context, _ mcp.CallToolRequest, args JiraListFiltersArgs) (*mcp.CallToolResult, error) {
+	filters, err := j.service.ListFilters(ctx, args.Name, args.MaxResults)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira list filters failed", err), nil
+	}
+
+	result := JiraFiltersResult{Filters: make([]JiraFilter, 0, len(filters))}
+	for _, f := range filters {
+		result.Filters = append(result.Filters, j.toJiraFilter(f))
+	}
+
+	fallback := fmt.Sprintf("Found %d Jira filters", len(result.Filters))
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (j *JiraTools) handleGetFilter(ctx context.Context, _ mcp.CallToolRequest, args JiraGetFilterArgs) (*mcp.CallToolResult, error) {
+	filter, err := j.service.GetFilter(ctx, args.ID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira get filter failed", err), nil
+	}
+
+	result := j.toJiraFilter(*filter)
+
+	fallback := fmt.Sprintf("Filter %s: %s", result.Name, result.JQL)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (j *JiraTools) handleCreateFilter(ctx context.Context, _ mcp.CallToolRequest, args JiraCreateFilterArgs) (*mcp.CallToolResult, error) {
+	jql := strings.TrimSpace(args.JQL)
+	if jql == "" {
+		jql = j.cache.LastJQL()
+	}
+	if jql == "" {
+		return mcp.NewToolResultError("JQL query must not be empty and no previous search is available"), nil
+	}
+
+	created, err := j.service.CreateFilter(ctx, jira.FilterInput{
+		Name:        args.Name,
+		JQL:         jql,
+		Description: args.Description,
+		Favourite:   args.Favourite,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira create filter failed", err), nil
+	}
+
+	result := j.toJiraFilter(*created)
+
+	fallback := fmt.Sprintf("Created filter %s (%s)", result.Name, result.ID)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (j *JiraTools) handleValidateJQL(ctx context.Context, _ mcp.CallToolRequest, args JiraValidateJQLArgs) (*mcp.CallToolResult, error) {
+	jql := strings.TrimSpace(args.JQL)
+	if jql == "" {
+		jql = j.cache.LastJQL()
+	}
+	if jql == "" {
+		return mcp.NewToolResultError("JQL query must not be empty and no previous search is available"), nil
+	}
+
+	validations, err := j.service.ValidateJQL(ctx, jql)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira validate jql failed", err), nil
+	}
+	if len(validations) == 0 {
+		return mcp.NewToolResultError("jira returned no validation result"), nil
+	}
+
+	result := JiraValidateJQLResult{JQLValidation: validations[0]}
+
+	fallback := "JQL is valid"
+	if !result.Valid {
+		messages := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+		fallback = "JQL is invalid: " + strings.Join(messages, "; ")
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (j *JiraTools) handleJQLSuggest(ctx context.Context, _ mcp.CallToolRequest, args JiraJQLSuggestArgs) (*mcp.CallToolResult, error) {
+	limit := args.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	result := JiraJQLSuggestResult{Suggestions: []JiraJQLSuggestion{}}
+
+	if args.FieldName != "" {
+		values, err := j.service.JQLValueSuggestions(ctx, args.FieldName, args.Prefix)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("jira jql suggest failed", err), nil
+		}
+
+		result.Kind = "value"
+		for _, v := range values {
+			result.Suggestions = append(result.Suggestions, JiraJQLSuggestion{Value: v.Value, DisplayName: v.DisplayName})
+		}
+	} else {
+		data, err := j.service.JQLAutocomplete(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("jira jql suggest failed", err), nil
+		}
+
+		result.Kind = "field"
+		for _, f := range jira.MatchJQLFields(data.Fields, args.Prefix) {
+			result.Suggestions = append(result.Suggestions, JiraJQLSuggestion{
+				Value:       f.Value,
+				DisplayName: f.DisplayName,
+				Operators:   f.Operators,
+			})
+		}
+	}
+
+	if len(result.Suggestions) > limit {
+		result.Suggestions = result.Suggestions[:limit]
+	}
+
+	fallback := fmt.Sprintf("Found %d JQL %s suggestions", len(result.Suggestions), result.Kind)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (j *JiraTools) toJiraFilter(f jira.Filter) JiraFilter {
+	return JiraFilter{
+		ID:          f.ID,
+		Name:        f.Name,
+		Description: f.Description,
+		JQL:         f.JQL,
+		Owner:       f.Owner.DisplayName,
+		Favourite:   f.Favourite,
+		URL:         fmt.Sprintf("%s/issues/?filter=%s", j.siteURL, f.ID),
+	}
+}
@@ -0,0 +1,225 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ylchen07/atlassian-mcp/internal/jira"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerHistoryTools registers changelog and flow analytics tools.
+func (j *JiraTools) registerHistoryTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"jira.issue_history",
+			mcp.WithDescription("Return an issue's field changes over time with time-in-status, cycle time and lead time"),
+			mcp.WithInputSchema[JiraIssueHistoryArgs](),
+			mcp.WithOutputSchema[JiraIssueHistoryResult](),
+		),
+		mcp.NewTypedToolHandler(j.handleIssueHistory),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.flow_metrics",
+			mcp.WithDescription("Aggregate lead time, cycle time and time-in-status percentiles plus weekly throughput over a JQL query"),
+			mcp.WithInputSchema[JiraFlowMetricsArgs](),
+			mcp.WithOutputSchema[JiraFlowMetricsResult](),
+		),
+		mcp.NewTypedToolHandler(j.handleFlowMetrics),
+	)
+}
+
+// JiraIssueHistoryArgs parameters for retrieving issue history.
+type JiraIssueHistoryArgs struct {
+	Key           string   `json:"key" jsonschema:"required" jsonschema_description:"Issue key"`
+	StartStatuses []string `json:"startStatuses,omitempty" jsonschema_description:"Statuses that start cycle time (default: first status change)"`
+	DoneStatuses  []string `json:"doneStatuses,omitempty" jsonschema_description:"Statuses that count as done when no resolution date is set"`
+	Fields        []string `json:"fields,omitempty" jsonschema_description:"Only return changes to these fields"`
+}
+
+// JiraFieldChange is a single field change.
+type JiraFieldChange struct {
+	Time   string `json:"time"`
+	Author string `json:"author,omitempty"`
+	Field  string `json:"field"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// JiraStatusPeriod is time spent in one status.
+type JiraStatusPeriod struct {
+	Status string  `json:"status"`
+	Start  string  `json:"start"`
+	End    string  `json:"end,omitempty"`
+	Hours  float64 `json:"hours"`
+}
+
+// JiraIssueHistoryResult describes the history of a single issue. Durations are in hours.
+type JiraIssueHistoryResult struct {
+	Key               string             `json:"key"`
+	Status            string             `json:"status"`
+	Created           string             `json:"created"`
+	Started           string             `json:"started,omitempty"`
+	Resolved          string             `json:"resolved,omitempty"`
+	LeadTimeHours     float64            `json:"leadTimeHours,omitempty"`
+	CycleTimeHours    float64            `json:"cycleTimeHours,omitempty"`
+	TimeInStatusHours map[string]float64 `json:"timeInStatusHours"`
+	Periods           []JiraStatusPeriod `json:"periods"`
+	Changes           []JiraFieldChange  `json:"changes"`
+	URL               string             `json:"url"`
+}
+
+// JiraFlowMetricsArgs parameters for aggregate flow metrics.
+type JiraFlowMetricsArgs struct {
+	JQL           string   `json:"jql" jsonschema:"required" jsonschema_description:"JQL selecting the issues to analyse"`
+	MaxIssues     int      `json:"maxIssues,omitempty" jsonschema_description:"Maximum number of issues to analyse" jsonschema:"minimum=1,maximum=1000"`
+	StartStatuses []string `json:"startStatuses,omitempty" jsonschema_description:"Statuses that start cycle time (default: first status change)"`
+	DoneStatuses  []string `json:"doneStatuses,omitempty" jsonschema_description:"Statuses that count as done when no resolution date is set"`
+}
+
+// JiraDurationStats summarises a duration distribution in hours.
+type JiraDurationStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P85   float64 `json:"p85"`
+	P95   float64 `json:"p95"`
+	Max   float64 `json:"max"`
+}
+
+// JiraFlowMetricsResult aggregates flow metrics. Durations are in hours.
+type JiraFlowMetricsResult struct {
+	Issues       int                          `json:"issues"`
+	Resolved     int                          `json:"resolved"`
+	Truncated    bool                         `json:"truncated"`
+	LeadTime     JiraDurationStats            `json:"leadTime"`
+	CycleTime    JiraDurationStats            `json:"cycleTime"`
+	TimeInStatus map[string]JiraDurationStats `json:"timeInStatus"`
+	Throughput   []jira.WeeklyThroughput      `json:"throughput"`
+	Failed       []jira.FlowFailure           `json:"failed,omitempty"`
+}
+
+func (j *JiraTools) handleIssueHistory(ctx context.Context, _ mcp.CallToolRequest, args JiraIssueHistoryArgs) (*mcp.CallToolResult, error) {
+	flow, err := j.service.IssueHistory(ctx, args.Key, jira.FlowOptions{
+		StartStatuses: args.StartStatuses,
+		DoneStatuses:  args.DoneStatuses,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira issue history failed", err), nil
+	}
+
+	result := JiraIssueHistoryResult{
+		Key:               flow.Key,
+		Status:            flow.Status,
+		Created:           formatTime(flow.Created),
+		Started:           formatTime(flow.Started),
+		Resolved:          formatTime(flow.Resolved),
+		LeadTimeHours:     hours(flow.LeadTime),
+		CycleTimeHours:    hours(flow.CycleTime),
+		TimeInStatusHours: make(map[string]float64, len(flow.TimeInStatus)),
+		Periods:           make([]JiraStatusPeriod, 0, len(flow.Periods)),
+		Changes:           make([]JiraFieldChange, 0, len(flow.Changes)),
+		URL:               fmt.Sprintf("%s/browse/%s", j.siteURL, flow.Key),
+	}
+
+	for status, d := range flow.TimeInStatus {
+		result.TimeInStatusHours[status] = hours(d)
+	}
+	for _, period := range flow.Periods {
+		result.Periods = append(result.Periods, JiraStatusPeriod{
+			Status: period.Status,
+			Start:  formatTime(period.Start),
+			End:    formatTime(period.End),
+			Hours:  hours(period.Duration),
+		})
+	}
+	for _, change := range flow.Changes {
+		if len(args.Fields) > 0 && !jira.ContainsFold(args.Fields, change.Field) {
+			continue
+		}
+		result.Changes = append(result.Changes, JiraFieldChange{
+			Time:   formatTime(change.Time),
+			Author: change.Author,
+			Field:  change.Field,
+			From:   change.From,
+			To:     change.To,
+		})
+	}
+
+	fallback := fmt.Sprintf("%s: %d changes across %d status periods", flow.Key, len(result.Changes), len(result.Periods))
+	if !flow.Resolved.IsZero() {
+		fallback += fmt.Sprintf("; lead time %.1fh, cycle time %.1fh", result.LeadTimeHours, result.CycleTimeHours)
+	}
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (j *JiraTools) handleFlowMetrics(ctx context.Context, _ mcp.CallToolRequest, args JiraFlowMetricsArgs) (*mcp.CallToolResult, error) {
+	if strings.TrimSpace(args.JQL) == "" {
+		return mcp.NewToolResultError("JQL query must not be empty"), nil
+	}
+
+	metrics, err := j.service.FlowMetrics(ctx, jira.FlowMetricsRequest{
+		JQL:       args.JQL,
+		MaxIssues: args.MaxIssues,
+		Options: jira.FlowOptions{
+			StartStatuses: args.StartStatuses,
+			DoneStatuses:  args.DoneStatuses,
+		},
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira flow metrics failed", err), nil
+	}
+
+	result := JiraFlowMetricsResult{
+		Issues:       metrics.Issues,
+		Resolved:     metrics.Resolved,
+		Truncated:    metrics.Truncated,
+		LeadTime:     toJiraDurationStats(metrics.LeadTime),
+		CycleTime:    toJiraDurationStats(metrics.CycleTime),
+		TimeInStatus: make(map[string]JiraDurationStats, len(metrics.TimeInStatus)),
+		Throughput:   metrics.Throughput,
+		Failed:       metrics.Failed,
+	}
+	for status, stats := range metrics.TimeInStatus {
+		result.TimeInStatus[status] = toJiraDurationStats(stats)
+	}
+	if result.Throughput == nil {
+		result.Throughput = []jira.WeeklyThroughput{}
+	}
+
+	j.cache.SetLastJQL(args.JQL)
+
+	fallback := fmt.Sprintf("Analysed %d issues (%d resolved): lead time p50 %.1fh, cycle time p50 %.1fh",
+		result.Issues, result.Resolved, result.LeadTime.P50, result.CycleTime.P50)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func toJiraDurationStats(stats jira.DurationStats) JiraDurationStats {
+	return JiraDurationStats{
+		Count: stats.Count,
+		Mean:  hours(stats.Mean),
+		P50:   hours(stats.P50),
+		P85:   hours(stats.P85),
+		P95:   hours(stats.P95),
+		Max:   hours(stats.Max),
+	}
+}
+
+// hours converts a duration to hours rounded to one decimal place.
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*10) / 10
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/jira"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// issueResourceFields are the issue fields rendered by jira://issue/{key}.
+var issueResourceFields = []string{
+	"summary", "description", "status", "issuetype", "priority", "assignee", "reporter",
+	"project", "labels", "created", "updated", "resolutiondate", "comment",
+}
+
+// registerResources exposes issues and projects as Markdown resources.
+func (j *JiraTools) registerResources(s *server.MCPServer) {
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			"jira://issue/{key}",
+			"Jira issue",
+			mcp.WithTemplateDescription("A Jira issue with its fields, description and comments, by key such as DEMO-123"),
+			mcp.WithTemplateMIMEType(markdownMIMEType),
+		),
+		j.readIssueResource,
+	)
+
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			"jira://project/{key}",
+			"Jira project",
+			mcp.WithTemplateDescription("A Jira project with its lead, issue types and components"),
+			mcp.WithTemplateMIMEType(markdownMIMEType),
+		),
+		j.readProjectResource,
+	)
+}
+
+func (j *JiraTools) readIssueResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	key, err := resourceArg(req, "key")
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := j.service.GetIssue(ctx, key, issueResourceFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return markdownResource(req, j.renderIssue(issue)), nil
+}
+
+func (j *JiraTools) readProjectResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	key, err := resourceArg(req, "key")
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := j.service.GetProject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return markdownResource(req, j.renderProject(project)), nil
+}
+
+func (j *JiraTools) renderIssue(issue *jira.Issue) string {
+	f := issue.Fields
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s: %s\n\n", issue.Key, f.Summary)
+
+	writeField(&b, "Type", f.IssueType.Name)
+	writeField(&b, "Status", f.Status.Name)
+	if f.Priority != nil {
+		writeField(&b, "Priority", f.Priority.Name)
+	}
+	assignee := f.Assignee.DisplayName
+	if assignee == "" {
+		assignee = "Unassigned"
+	}
+	writeField(&b, "Assignee", assignee)
+	if f.Reporter != nil {
+		writeField(&b, "Reporter", f.Reporter.DisplayName)
+	}
+	writeField(&b, "Project", f.Project.Key)
+	writeField(&b, "Labels", strings.Join(f.Labels, ", "))
+	writeField(&b, "Created", f.Created)
+	writeField(&b, "Updated", f.Updated)
+	writeField(&b, "Resolved", f.ResolutionDate)
+	writeField(&b, "Link", fmt.Sprintf("%s/browse/%s", j.siteURL, issue.Key))
+
+	if description := jira.TextToMarkdown(f.Description); description != "" {
+		fmt.Fprintf(&b, "\n## Description\n\n%s\n", description)
+	}
+
+	if f.Comment != nil && len(f.Comment.Comments) > 0 {
+		b.WriteString("\n## Comments\n")
+		if f.Comment.Total > len(f.Comment.Comments) {
+			fmt.Fprintf(&b, "\nShowing %d of %d comments.\n", len(f.Comment.Comments), f.Comment.Total)
+		}
+		for _, comment := range f.Comment.Comments {
+			fmt.Fprintf(&b, "\n### %s, %s\n\n%s\n", comment.Author.DisplayName, comment.Created, jira.TextToMarkdown(comment.Body))
+		}
+	}
+
+	return b.String()
+}
+
+func (j *JiraTools) renderProject(project *jira.ProjectDetails) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s (%s)\n\n", project.Name, project.Key)
+
+	writeField(&b, "Lead", project.Lead.DisplayName)
+	writeField(&b, "Type", project.ProjectTypeKey)
+	writeField(&b, "Link", fmt.Sprintf("%s/browse/%s", j.siteURL, project.Key))
+
+	if description := strings.TrimSpace(project.Description); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", jira.TextToMarkdown(description))
+	}
+
+	if len(project.IssueTypes) > 0 {
+		b.WriteString("\n## Issue types\n\n")
+		for _, issueType := range project.IssueTypes {
+			if issueType.Subtask {
+				fmt.Fprintf(&b, "- %s (sub-task)\n", issueType.Name)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", issueType.Name)
+			}
+		}
+	}
+
+	if len(project.Components) > 0 {
+		b.WriteString("\n## Components\n\n")
+		for _, component := range project.Components {
+			if component.Description != "" {
+				fmt.Fprintf(&b, "- %s: %s\n", component.Name, component.Description)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", component.Name)
+			}
+		}
+	}
+
+	return b.String()
+}
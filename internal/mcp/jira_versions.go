@@ -0,0 +1,302 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ylchen07/atlassian-mcp/internal/jira"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerVersionTools registers project version, component and release note tools.
+func (j *JiraTools) registerVersionTools(s *server.MCPServer) {
+	s.AddTool(
+		mcp.NewTool(
+			"jira.list_versions",
+			mcp.WithDescription("List versions (releases) of a Jira project"),
+			mcp.WithInputSchema[JiraProjectKeyArgs](),
+			mcp.WithOutputSchema[JiraVersionsResult](),
+		),
+		mcp.NewTypedToolHandler(j.handleListVersions),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.create_version",
+			mcp.WithDescription("Create a version (release) in a Jira project"),
+			mcp.WithInputSchema[JiraCreateVersionArgs](),
+			mcp.WithOutputSchema[jira.Version](),
+		),
+		mcp.NewTypedToolHandler(j.handleCreateVersion),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.update_version",
+			mcp.WithDescription("Update the name, description or dates of a Jira version"),
+			mcp.WithInputSchema[JiraUpdateVersionArgs](),
+			mcp.WithOutputSchema[jira.Version](),
+		),
+		mcp.NewTypedToolHandler(j.handleUpdateVersion),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.release_version",
+			mcp.WithDescription("Mark a Jira version as released"),
+			mcp.WithInputSchema[JiraReleaseVersionArgs](),
+			mcp.WithOutputSchema[jira.Version](),
+		),
+		mcp.NewTypedToolHandler(j.handleReleaseVersion),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.archive_version",
+			mcp.WithDescription("Archive or unarchive a Jira version"),
+			mcp.WithInputSchema[JiraArchiveVersionArgs](),
+			mcp.WithOutputSchema[jira.Version](),
+		),
+		mcp.NewTypedToolHandler(j.handleArchiveVersion),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.list_components",
+			mcp.WithDescription("List components of a Jira project"),
+			mcp.WithInputSchema[JiraProjectKeyArgs](),
+			mcp.WithOutputSchema[JiraComponentsResult](),
+		),
+		mcp.NewTypedToolHandler(j.handleListComponents),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.create_component",
+			mcp.WithDescription("Create a component in a Jira project"),
+			mcp.WithInputSchema[JiraCreateComponentArgs](),
+			mcp.WithOutputSchema[jira.Component](),
+		),
+		mcp.NewTypedToolHandler(j.handleCreateComponent),
+	)
+
+	s.AddTool(
+		mcp.NewTool(
+			"jira.release_notes",
+			mcp.WithDescription("Render Markdown release notes for the issues in a fix version, grouped by issue type"),
+			mcp.WithInputSchema[JiraReleaseNotesArgs](),
+			mcp.WithOutputSchema[JiraReleaseNotesResult](),
+		),
+		mcp.NewTypedToolHandler(j.handleReleaseNotes),
+	)
+}
+
+// JiraProjectKeyArgs parameters for project-scoped listings.
+type JiraProjectKeyArgs struct {
+	ProjectKey string `json:"projectKey" jsonschema:"required" jsonschema_description:"Project key"`
+}
+
+// JiraVersionsResult wraps the version list response.
+type JiraVersionsResult struct {
+	Versions []jira.Version `json:"versions"`
+}
+
+// JiraCreateVersionArgs parameters for creating a version.
+type JiraCreateVersionArgs struct {
+	ProjectKey  string `json:"projectKey" jsonschema:"required" jsonschema_description:"Project key"`
+	Name        string `json:"name" jsonschema:"required" jsonschema_description:"Version name"`
+	Description string `json:"description,omitempty" jsonschema_description:"Version description"`
+	StartDate   string `json:"startDate,omitempty" jsonschema_description:"Start date (YYYY-MM-DD)"`
+	ReleaseDate string `json:"releaseDate,omitempty" jsonschema_description:"Planned release date (YYYY-MM-DD)"`
+}
+
+// JiraUpdateVersionArgs parameters for updating a version.
+type JiraUpdateVersionArgs struct {
+	ID          string `json:"id" jsonschema:"required" jsonschema_description:"Version ID"`
+	Name        string `json:"name,omitempty" jsonschema_description:"New version name"`
+	Description string `json:"description,omitempty" jsonschema_description:"New description"`
+	StartDate   string `json:"startDate,omitempty" jsonschema_description:"Start date (YYYY-MM-DD)"`
+	ReleaseDate string `json:"releaseDate,omitempty" jsonschema_description:"Release date (YYYY-MM-DD)"`
+}
+
+// JiraReleaseVersionArgs parameters for releasing a version.
+type JiraReleaseVersionArgs struct {
+	ID          string `json:"id" jsonschema:"required" jsonschema_description:"Version ID"`
+	ReleaseDate string `json:"releaseDate,omitempty" jsonschema_description:"Release date (YYYY-MM-DD), defaults to today"`
+}
+
+// JiraArchiveVersionArgs parameters for archiving a version.
+type JiraArchiveVersionArgs struct {
+	ID        string `json:"id" jsonschema:"required" jsonschema_description:"Version ID"`
+	Unarchive bool   `json:"unarchive,omitempty" jsonschema_description:"Restore an archived version instead"`
+}
+
+// JiraComponentsResult wraps the component list response.
+type JiraComponentsResult struct {
+	Components []jira.Component `json:"components"`
+}
+
+// JiraCreateComponentArgs parameters for creating a component.
+type JiraCreateComponentArgs struct {
+	ProjectKey    string `json:"projectKey" jsonschema:"required" jsonschema_description:"Project key"`
+	Name          string `json:"name" jsonschema:"required" jsonschema_description:"Component name"`
+	Description   string `json:"description,omitempty" jsonschema_description:"Component description"`
+	LeadAccountID string `json:"leadAccountId,omitempty" jsonschema_description:"Component lead account ID"`
+	AssigneeType  string `json:"assigneeType,omitempty" jsonschema:"enum=PROJECT_DEFAULT,enum=COMPONENT_LEAD,enum=PROJECT_LEAD,enum=UNASSIGNED" jsonschema_description:"Default assignee strategy"`
+}
+
+// JiraReleaseNotesArgs parameters for generating release notes.
+type JiraReleaseNotesArgs struct {
+	ProjectKey string `json:"projectKey" jsonschema:"required" jsonschema_description:"Project key"`
+	Version    string `json:"version" jsonschema:"required" jsonschema_description:"Fix version name"`
+}
+
+// JiraReleaseNotesResult carries rendered release notes.
+type JiraReleaseNotesResult struct {
+	ProjectKey string `json:"projectKey"`
+	Version    string `json:"version"`
+	Total      int    `json:"total"`
+	Markdown   string `json:"markdown"`
+}
+
+func (j *JiraTools) handleListVersions(ctx context.Context, _ mcp.CallToolRequest, args JiraProjectKeyArgs) (*mcp.CallToolResult, error) {
+	versions, err := j.service.ListVersions(ctx, args.ProjectKey)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira list versions failed", err), nil
+	}
+
+	result := JiraVersionsResult{Versions: versions}
+	if result.Versions == nil {
+		result.Versions = []jira.Version{}
+	}
+
+	fallback := fmt.Sprintf("Found %d versions in %s", len(result.Versions), args.ProjectKey)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (j *JiraTools) handleCreateVersion(ctx context.Context, _ mcp.CallToolRequest, args JiraCreateVersionArgs) (*mcp.CallToolResult, error) {
+	created, err := j.service.CreateVersion(ctx, jira.VersionInput{
+		ProjectKey:  args.ProjectKey,
+		Name:        args.Name,
+		Description: args.Description,
+		StartDate:   args.StartDate,
+		ReleaseDate: args.ReleaseDate,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira create version failed", err), nil
+	}
+
+	fallback := fmt.Sprintf("Created version %s (%s) in %s", created.Name, created.ID, args.ProjectKey)
+	return mcp.NewToolResultStructured(created, fallback), nil
+}
+
+func (j *JiraTools) handleUpdateVersion(ctx context.Context, _ mcp.CallToolRequest, args JiraUpdateVersionArgs) (*mcp.CallToolResult, error) {
+	updated, err := j.service.UpdateVersion(ctx, args.ID, jira.VersionInput{
+		Name:        args.Name,
+		Description: args.Description,
+		StartDate:   args.StartDate,
+		ReleaseDate: args.ReleaseDate,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira update version failed", err), nil
+	}
+
+	fallback := fmt.Sprintf("Updated version %s", updated.Name)
+	return mcp.NewToolResultStructured(updated, fallback), nil
+}
+
+func (j *JiraTools) handleReleaseVersion(ctx context.Context, _ mcp.CallToolRequest, args JiraReleaseVersionArgs) (*mcp.CallToolResult, error) {
+	released, err := j.service.ReleaseVersion(ctx, args.ID, args.ReleaseDate)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira release version failed", err), nil
+	}
+
+	fallback := fmt.Sprintf("Released version %s on %s", released.Name, released.ReleaseDate)
+	return mcp.NewToolResultStructured(released, fallback), nil
+}
+
+func (j *JiraTools) handleArchiveVersion(ctx context.Context, _ mcp.CallToolRequest, args JiraArchiveVersionArgs) (*mcp.CallToolResult, error) {
+	version, err := j.service.ArchiveVersion(ctx, args.ID, !args.Unarchive)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira archive version failed", err), nil
+	}
+
+	action := "Archived"
+	if args.Unarchive {
+		action = "Unarchived"
+	}
+
+	fallback := fmt.Sprintf("%s version %s", action, version.Name)
+	return mcp.NewToolResultStructured(version, fallback), nil
+}
+
+func (j *JiraTools) handleListComponents(ctx context.Context, _ mcp.CallToolRequest, args JiraProjectKeyArgs) (*mcp.CallToolResult, error) {
+	components, err := j.service.ListComponents(ctx, args.ProjectKey)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira list components failed", err), nil
+	}
+
+	result := JiraComponentsResult{Components: components}
+	if result.Components == nil {
+		result.Components = []jira.Component{}
+	}
+
+	fallback := fmt.Sprintf("Found %d components in %s", len(result.Components), args.ProjectKey)
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func (j *JiraTools) handleCreateComponent(ctx context.Context, _ mcp.CallToolRequest, args JiraCreateComponentArgs) (*mcp.CallToolResult, error) {
+	created, err := j.service.CreateComponent(ctx, jira.ComponentInput{
+		ProjectKey:    args.ProjectKey,
+		Name:          args.Name,
+		Description:   args.Description,
+		LeadAccountID: args.LeadAccountID,
+		AssigneeType:  args.AssigneeType,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira create component failed", err), nil
+	}
+
+	fallback := fmt.Sprintf("Created component %s (%s) in %s", created.Name, created.ID, args.ProjectKey)
+	return mcp.NewToolResultStructured(created, fallback), nil
+}
+
+func (j *JiraTools) handleReleaseNotes(ctx context.Context, _ mcp.CallToolRequest, args JiraReleaseNotesArgs) (*mcp.CallToolResult, error) {
+	notes, err := j.service.ReleaseNotes(ctx, args.ProjectKey, args.Version)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("jira release notes failed", err), nil
+	}
+
+	markdown := renderReleaseNotes(notes, j.siteURL)
+	result := JiraReleaseNotesResult{
+		ProjectKey: notes.ProjectKey,
+		Version:    notes.Version,
+		Total:      notes.Total,
+		Markdown:   markdown,
+	}
+
+	return mcp.NewToolResultStructured(result, markdown), nil
+}
+
+// renderReleaseNotes formats release notes as Markdown with one section per issue type.
+func renderReleaseNotes(notes *jira.ReleaseNotes, siteURL string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s %s\n", notes.ProjectKey, notes.Version)
+
+	if len(notes.Groups) == 0 {
+		b.WriteString("\nNo issues are assigned to this version.\n")
+		return b.String()
+	}
+
+	for _, group := range notes.Groups {
+		fmt.Fprintf(&b, "\n## %s\n\n", group.IssueType)
+		for _, issue := range group.Issues {
+			fmt.Fprintf(&b, "- [%s](%s/browse/%s) %s\n", issue.Key, siteURL, issue.Key, issue.Fields.Summary)
+		}
+	}
+
+	return b.String()
+}
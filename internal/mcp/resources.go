@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// markdownMIMEType is the MIME type of every resource the server exposes.
+const markdownMIMEType = "text/markdown"
+
+// resourceArg returns a variable matched from a resource template URI.
+func resourceArg(req mcp.ReadResourceRequest, name string) (string, error) {
+	var value string
+	switch v := req.Params.Arguments[name].(type) {
+	case string:
+		value = v
+	case []string:
+		value = strings.Join(v, ",")
+	}
+	if value = strings.TrimSpace(value); value == "" {
+		return "", fmt.Errorf("resource %s: missing %s", req.Params.URI, name)
+	}
+	return value, nil
+}
+
+func markdownResource(req mcp.ReadResourceRequest, text string) []mcp.ResourceContents {
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: markdownMIMEType,
+			Text:     text,
+		},
+	}
+}
+
+// writeField writes a "- **Name:** value" line when value is set.
+func writeField(b *strings.Builder, name, value string) {
+	if value != "" {
+		fmt.Fprintf(b, "- **%s:** %s\n", name, value)
+	}
+}
@@ -20,7 +20,8 @@ type Dependencies struct {
 	Logger            *slog.Logger
 }
 
-// NewServer builds an MCP server with registered Jira and Confluence tools.
+// NewServer builds an MCP server with registered Jira and Confluence tools,
+// and resource templates for issues, projects, pages and spaces.
 func NewServer(deps Dependencies) *server.MCPServer {
 	if deps.Logger == nil {
 		deps.Logger = slog.Default()
@@ -30,6 +31,7 @@ func NewServer(deps Dependencies) *server.MCPServer {
 		"Atlassian MCP",
 		"0.1.0",
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(false, false),
 		server.WithInstructions("Tools for Jira and Confluence operations."),
 		server.WithRecovery(),
 	)
@@ -39,11 +41,13 @@ func NewServer(deps Dependencies) *server.MCPServer {
 	}
 
 	if deps.JiraService != nil {
-		NewJiraTools(srv, deps.JiraService, deps.Cache, deps.JiraBaseURL)
+		jiraTools := NewJiraTools(srv, deps.JiraService, deps.Cache, deps.JiraBaseURL)
+		jiraTools.registerResources(srv)
 	}
 
 	if deps.ConfluenceService != nil {
-		NewConfluenceTools(srv, deps.ConfluenceService, deps.ConfluenceBaseURL)
+		confluenceTools := NewConfluenceTools(srv, deps.ConfluenceService, deps.ConfluenceBaseURL)
+		confluenceTools.registerResources(srv)
 	}
 
 	return srv
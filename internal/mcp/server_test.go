@@ -2,7 +2,10 @@ package mcp
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -35,11 +38,63 @@ func TestNewServerRegistersExpectedTools(t *testing.T) {
 		"jira.list_transitions",
 		"jira.transition_issue",
 		"jira.add_attachment",
+		"jira.bulk_update",
+		"jira.list_versions",
+		"jira.create_version",
+		"jira.update_version",
+		"jira.release_version",
+		"jira.archive_version",
+		"jira.list_components",
+		"jira.create_component",
+		"jira.release_notes",
+		"jira.issue_history",
+		"jira.flow_metrics",
+		"jira.list_filters",
+		"jira.get_filter",
+		"jira.create_filter",
+		"jira.validate_jql",
+		"jira.jql_suggest",
 		"confluence.list_spaces",
 		"confluence.search_pages",
 		"confluence.create_page",
 		"confluence.update_page",
 		"confluence.get_page",
+		"confluence.get_page_tree",
+		"confluence.delete_page",
+		"confluence.purge_page",
+		"confluence.restore_page",
+		"confluence.move_page",
+		"confluence.list_versions",
+		"confluence.diff_versions",
+		"confluence.restore_version",
+		"confluence.edit_section",
+		"confluence.list_labels",
+		"confluence.add_labels",
+		"confluence.remove_labels",
+		"confluence.search_by_label",
+		"confluence.list_comments",
+		"confluence.add_comment",
+		"confluence.resolve_comment",
+		"confluence.upload_attachment",
+		"confluence.list_attachments",
+		"confluence.download_attachment",
+		"confluence.list_blog_posts",
+		"confluence.list_templates",
+		"confluence.get_template",
+		"confluence.create_from_template",
+		"confluence.get_restrictions",
+		"confluence.update_restrictions",
+		"confluence.check_permission",
+		"confluence.copy_page",
+		"confluence.get_space",
+		"confluence.create_space",
+		"confluence.archive_space",
+		"confluence.unarchive_space",
+		"confluence.get_properties",
+		"confluence.set_property",
+		"confluence.delete_property",
+		"confluence.stamp_page",
+		"confluence.export",
 	}
 
 	if len(tools) != len(expected) {
@@ -65,9 +120,8 @@ func TestNewJiraToolsTrimsSiteURL(t *testing.T) {
 		t.Fatalf("expected trimmed site URL, got %s", jt.siteURL)
 	}
 
-	// All 8 Jira tools are now implemented
-	if len(srv.ListTools()) != 8 {
-		t.Fatalf("expected 8 jira tools, got %d", len(srv.ListTools()))
+	if len(srv.ListTools()) != 24 {
+		t.Fatalf("expected 24 jira tools, got %d", len(srv.ListTools()))
 	}
 }
 
@@ -88,6 +142,112 @@ func TestJiraToolsHandleSearchIssuesValidation(t *testing.T) {
 	}
 }
 
+func TestJiraToolsHandleTransitionIssueRequiresTarget(t *testing.T) {
+	t.Parallel()
+
+	jt := &JiraTools{cache: state.NewCache(), siteURL: "https://example"}
+
+	res, err := jt.handleTransitionIssue(context.Background(), mcp.CallToolRequest{}, JiraTransitionIssueArgs{Key: "PROJ-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.IsError {
+		t.Fatalf("expected error result")
+	}
+	if got := firstText(res); got != "either transitionId or to must be provided" {
+		t.Fatalf("unexpected message: %s", got)
+	}
+}
+
+func TestJiraToolsHandleCreateFilterRequiresJQL(t *testing.T) {
+	t.Parallel()
+
+	jt := &JiraTools{cache: state.NewCache(), siteURL: "https://example"}
+
+	res, err := jt.handleCreateFilter(context.Background(), mcp.CallToolRequest{}, JiraCreateFilterArgs{Name: "Open bugs"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.IsError {
+		t.Fatalf("expected error result")
+	}
+	if got := firstText(res); got != "JQL query must not be empty and no previous search is available" {
+		t.Fatalf("unexpected message: %s", got)
+	}
+}
+
+func TestRenderReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	bug := jira.Issue{Key: "DEMO-2"}
+	bug.Fields.Summary = "Fix crash"
+	story := jira.Issue{Key: "DEMO-1"}
+	story.Fields.Summary = "Add export"
+
+	notes := &jira.ReleaseNotes{
+		ProjectKey: "DEMO",
+		Version:    "1.2.0",
+		Total:      2,
+		Groups: []jira.ReleaseNoteGroup{
+			{IssueType: "Bug", Issues: []jira.Issue{bug}},
+			{IssueType: "Story", Issues: []jira.Issue{story}},
+		},
+	}
+
+	got := renderReleaseNotes(notes, "https://example")
+	want := "# DEMO 1.2.0\n\n## Bug\n\n- [DEMO-2](https://example/browse/DEMO-2) Fix crash\n\n## Story\n\n- [DEMO-1](https://example/browse/DEMO-1) Add export\n"
+	if got != want {
+		t.Fatalf("unexpected release notes:\n%s", got)
+	}
+}
+
+func TestNewServerRegistersResourceTemplates(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(Dependencies{JiraService: &jira.Service{}, ConfluenceService: &confluence.Service{}})
+
+	res := srv.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/templates/list"}`))
+	resp, ok := res.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("unexpected response: %#v", res)
+	}
+	result, ok := resp.Result.(mcp.ListResourceTemplatesResult)
+	if !ok {
+		t.Fatalf("unexpected result: %#v", resp.Result)
+	}
+
+	templates := map[string]string{}
+	for _, template := range result.ResourceTemplates {
+		templates[template.URITemplate.Raw()] = template.MIMEType
+	}
+	for _, uri := range []string{"jira://issue/{key}", "jira://project/{key}", "confluence://page/{id}", "confluence://space/{key}"} {
+		if templates[uri] != "text/markdown" {
+			t.Fatalf("resource template %q not registered: %v", uri, templates)
+		}
+	}
+}
+
+func TestRenderIssueResource(t *testing.T) {
+	t.Parallel()
+
+	jt := &JiraTools{siteURL: "https://example"}
+
+	issue := &jira.Issue{Key: "DEMO-1"}
+	issue.Fields.Summary = "Fix login"
+	issue.Fields.Status.Name = "In Progress"
+	issue.Fields.IssueType.Name = "Bug"
+	issue.Fields.Labels = []string{"auth", "web"}
+	issue.Fields.Description = "h2. Steps\n* Open *login*"
+
+	got := jt.renderIssue(issue)
+	want := "# DEMO-1: Fix login\n\n" +
+		"- **Type:** Bug\n- **Status:** In Progress\n- **Assignee:** Unassigned\n- **Labels:** auth, web\n- **Link:** https://example/browse/DEMO-1\n\n" +
+		"## Description\n\n## Steps\n- Open **login**\n"
+	if got != want {
+		t.Fatalf("unexpected issue resource:\n%s", got)
+	}
+}
+
 // TEMPORARY: Tests for unimplemented handlers - will be restored when methods are added
 // func TestJiraToolsHandleUpdateIssueValidation(t *testing.T) {
 // 	t.Parallel()
@@ -134,8 +294,8 @@ func TestNewConfluenceToolsTrimsBaseURL(t *testing.T) {
 		t.Fatalf("expected trimmed base URL, got %s", ct.baseURL)
 	}
 
-	if len(srv.ListTools()) != 5 {
-		t.Fatalf("expected 5 confluence tools, got %d", len(srv.ListTools()))
+	if len(srv.ListTools()) != 41 {
+		t.Fatalf("expected 41 confluence tools, got %d", len(srv.ListTools()))
 	}
 }
 
@@ -156,6 +316,146 @@ func TestConfluenceToolsHandleSearchContentValidation(t *testing.T) {
 	}
 }
 
+func TestSearchFiltersValidation(t *testing.T) {
+	t.Parallel()
+
+	ct := &ConfluenceTools{baseURL: "https://example"}
+	res, err := ct.handleSearchContent(context.Background(), mcp.CallToolRequest{}, ConfluenceSearchArgs{
+		CQL:     "type = page",
+		Filters: &ConfluenceSearchFilters{Spaces: []string{"OPS"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := firstText(res); !res.IsError || got != "provide either cql or filters, not both" {
+		t.Fatalf("unexpected result: %s", got)
+	}
+
+	jt := &JiraTools{cache: state.NewCache(), siteURL: "https://example"}
+	res, err = jt.handleSearchIssues(context.Background(), mcp.CallToolRequest{}, JiraSearchIssuesArgs{
+		Filters: &JiraSearchFilters{Projects: []string{"OPS"}, UpdatedAfter: "yesterday"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := firstText(res); !res.IsError || !strings.Contains(got, "unsupported date") {
+		t.Fatalf("unexpected result: %s", got)
+	}
+}
+
+func TestConfluenceToolsHandleGetPageRequiresReference(t *testing.T) {
+	t.Parallel()
+
+	ct := &ConfluenceTools{baseURL: "https://example"}
+
+	res, err := ct.handleGetPage(context.Background(), mcp.CallToolRequest{}, ConfluenceGetPageArgs{SpaceKey: "ENG"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.IsError {
+		t.Fatalf("expected error result")
+	}
+	if got := firstText(res); got != "one of id, url, or spaceKey and title must be provided" {
+		t.Fatalf("unexpected message: %s", got)
+	}
+}
+
+func TestConfluenceToolsHandleUpdatePageVersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	ct := &ConfluenceTools{baseURL: "https://example"}
+
+	res, err := ct.handleUpdatePage(context.Background(), mcp.CallToolRequest{}, ConfluenceUpdateArgs{ID: "1", Body: "<p>x</p>", Version: 5, ExpectedVersion: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.IsError {
+		t.Fatalf("expected error result")
+	}
+	if got := firstText(res); got != "version 5 does not follow expectedVersion 3" {
+		t.Fatalf("unexpected message: %s", got)
+	}
+}
+
+func TestConfluenceToolsHandleExportRejectsNonLocalDir(t *testing.T) {
+	t.Parallel()
+
+	ct := &ConfluenceTools{baseURL: "https://example"}
+
+	for _, dir := range []string{"/etc", "../outside", ""} {
+		res, err := ct.handleExport(context.Background(), mcp.CallToolRequest{}, ConfluenceExportArgs{SpaceKey: "ENG", Dir: dir})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !res.IsError || firstText(res) != "dir must be a relative path inside the server's working directory" {
+			t.Fatalf("expected %q to be rejected, got %s", dir, firstText(res))
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Time{
+		"2024-03-01T09:30:00Z": time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+		"2024-03-01":           time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"7d":                   time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC),
+		"2w":                   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	for input, want := range tests {
+		got, err := parseSince(input, now)
+		if err != nil {
+			t.Fatalf("parseSince(%q) error: %v", input, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("parseSince(%q) = %s, want %s", input, got, want)
+		}
+	}
+
+	if _, err := parseSince("last week", now); err == nil {
+		t.Fatal("expected error for unrecognised input")
+	}
+}
+
+func TestThreadComments(t *testing.T) {
+	t.Parallel()
+
+	var comments []confluence.Comment
+	add := func(id, parent, location, resolution string) {
+		comment := confluence.Comment{}
+		comment.ID = id
+		comment.Extensions.Location = location
+		if parent != "" {
+			comment.Ancestors = []confluence.Content{{ID: parent, Type: "comment"}}
+		}
+		if resolution != "" {
+			comment.Extensions.Resolution = &struct {
+				Status string `json:"status"`
+			}{Status: resolution}
+		}
+		comments = append(comments, comment)
+	}
+	add("1", "", "footer", "")
+	add("2", "", "inline", "resolved")
+	add("3", "1", "footer", "")
+	add("4", "2", "inline", "")
+	add("5", "3", "footer", "")
+
+	var got []string
+	for _, comment := range threadComments(comments, false) {
+		got = append(got, fmt.Sprintf("%s@%d", comment.ID, comment.Depth))
+	}
+	if strings.Join(got, ",") != "1@0,3@1,5@2" {
+		t.Fatalf("unexpected thread order: %v", got)
+	}
+
+	if all := threadComments(comments, true); len(all) != 5 || all[3].ID != "2" || all[4].ID != "4" {
+		t.Fatalf("expected resolved thread to be included, got %+v", all)
+	}
+}
+
 func firstText(res *mcp.CallToolResult) string {
 	if len(res.Content) == 0 {
 		return ""
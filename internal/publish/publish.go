@@ -0,0 +1,659 @@
+// Package publish mirrors a local directory of Markdown files into a
+// Confluence page tree.
+package publish
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+)
+
+// MappingFile records the page each file was published to and the content
+// hash it was published with.
+const MappingFile = ".confluence-publish.json"
+
+// Page statuses reported by Publish.
+const (
+	StatusCreated   = "created"
+	StatusUpdated   = "updated"
+	StatusUnchanged = "unchanged"
+)
+
+// Options selects what to publish and where.
+type Options struct {
+	Dir      string
+	SpaceKey string
+	// ParentID is the page the tree is published under; the space homepage
+	// when empty.
+	ParentID string
+	// DryRun reports what would change without writing to Confluence or
+	// the mapping file.
+	DryRun   bool
+	Progress func(done, total int, page Page)
+}
+
+// Page reports the outcome for one local file or directory.
+type Page struct {
+	Path        string
+	ID          string
+	Title       string
+	Status      string
+	Attachments int
+}
+
+// Report summarises a publish run.
+type Report struct {
+	Pages     []Page
+	Created   int
+	Updated   int
+	Unchanged int
+	// Missing lists mapped files that no longer exist. Their pages are left
+	// in Confluence.
+	Missing []string
+}
+
+// Publisher writes local Markdown trees to Confluence.
+type Publisher struct {
+	service *confluence.Service
+}
+
+// NewPublisher constructs a Publisher.
+func NewPublisher(service *confluence.Service) *Publisher {
+	return &Publisher{service: service}
+}
+
+type mapping struct {
+	SpaceKey string                  `json:"spaceKey"`
+	Pages    map[string]mappingEntry `json:"pages"`
+}
+
+type mappingEntry struct {
+	ID          string            `json:"id"`
+	Hash        string            `json:"hash"`
+	Version     int               `json:"version,omitempty"`
+	Labels      []string          `json:"labels,omitempty"`
+	Attachments map[string]string `json:"attachments,omitempty"`
+}
+
+// Publish creates or updates one page per Markdown file. Directories become
+// pages too: their body comes from a sibling NAME.md or an index.md or
+// README.md inside them, and their files become child pages. A file's page
+// is found through the mapping file, an id in its front-matter, or an
+// existing page with the same title, in that order. Files whose title,
+// body, labels, attachments and parent are unchanged since the last run are
+// skipped without calling Confluence. Labels dropped from a file's
+// front-matter are removed from its page; labels added in Confluence are
+// kept. A page edited in Confluence since the last run is not overwritten.
+// Hidden entries and entries starting with an underscore are ignored.
+func (p *Publisher) Publish(ctx context.Context, opts Options) (*Report, error) {
+	if strings.TrimSpace(opts.Dir) == "" {
+		return nil, fmt.Errorf("publish: directory required")
+	}
+	if opts.SpaceKey == "" {
+		return nil, fmt.Errorf("publish: space key required")
+	}
+
+	docs, err := scan(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("publish: no Markdown files in %s", opts.Dir)
+	}
+
+	previous, err := loadMapping(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if previous.SpaceKey != "" && !strings.EqualFold(previous.SpaceKey, opts.SpaceKey) {
+		return nil, fmt.Errorf("publish: %s maps %s to space %s; remove it to publish to %s", MappingFile, opts.Dir, previous.SpaceKey, opts.SpaceKey)
+	}
+
+	rootID := opts.ParentID
+	if rootID == "" {
+		space, err := p.service.GetSpace(ctx, opts.SpaceKey)
+		if err != nil {
+			return nil, err
+		}
+		if space.Homepage == nil || space.Homepage.ID == "" {
+			return nil, fmt.Errorf("publish: space %s has no homepage; pass a parent page", opts.SpaceKey)
+		}
+		rootID = space.Homepage.ID
+	}
+
+	scanned := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		scanned[d.key] = true
+	}
+
+	current := mapping{SpaceKey: opts.SpaceKey, Pages: map[string]mappingEntry{}}
+	save := func(err error) error {
+		if opts.DryRun {
+			return err
+		}
+		for key, entry := range previous.Pages {
+			if _, ok := current.Pages[key]; !ok && scanned[key] {
+				current.Pages[key] = entry
+			}
+		}
+		if saveErr := saveMapping(opts.Dir, current); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		return err
+	}
+
+	report := &Report{}
+	for i, d := range docs {
+		parentID := rootID
+		if d.parent != nil {
+			parentID = current.Pages[d.parent.key].ID
+		}
+
+		page, entry, err := p.publishOne(ctx, opts, d, parentID, previous.Pages[d.key])
+		if err != nil {
+			if entry.ID != "" {
+				current.Pages[d.key] = entry
+			}
+			return nil, save(fmt.Errorf("publish %s: %w", d.key, err))
+		}
+		current.Pages[d.key] = entry
+
+		switch page.Status {
+		case StatusCreated:
+			report.Created++
+		case StatusUpdated:
+			report.Updated++
+		default:
+			report.Unchanged++
+		}
+		report.Pages = append(report.Pages, page)
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(docs), page)
+		}
+	}
+
+	for key := range previous.Pages {
+		if _, ok := current.Pages[key]; !ok {
+			report.Missing = append(report.Missing, key)
+		}
+	}
+	sort.Strings(report.Missing)
+
+	if err := save(nil); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (p *Publisher) publishOne(ctx context.Context, opts Options, d *doc, parentID string, previous mappingEntry) (Page, mappingEntry, error) {
+	page := Page{Path: d.key, Title: d.title, Status: StatusUnchanged, Attachments: len(d.attachments)}
+
+	attachments := map[string]string{}
+	for name, file := range d.attachments {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return page, mappingEntry{}, err
+		}
+		attachments[name] = hashOf(data)
+	}
+
+	entry := mappingEntry{ID: previous.ID, Hash: d.hash(parentID, attachments), Version: previous.Version, Labels: d.labels, Attachments: attachments}
+	if len(attachments) == 0 {
+		entry.Attachments = nil
+	}
+	if entry.ID == "" {
+		entry.ID = d.frontID
+	}
+	if entry.ID != "" && entry.ID == previous.ID && entry.Hash == previous.Hash {
+		page.ID = entry.ID
+		return page, entry, nil
+	}
+
+	if entry.ID == "" {
+		existing, err := p.service.FindPage(ctx, opts.SpaceKey, d.title, nil)
+		switch {
+		case err == nil:
+			entry.ID = existing.ID
+		case !errors.Is(err, confluence.ErrNotFound):
+			return page, mappingEntry{}, err
+		}
+	}
+
+	// Failures leave the entry without a hash so the next run retries the
+	// page.
+	partial := mappingEntry{ID: entry.ID, Version: previous.Version, Labels: previous.Labels}
+
+	if entry.ID == "" {
+		page.Status = StatusCreated
+		if opts.DryRun {
+			return page, entry, nil
+		}
+		created, err := p.service.CreatePage(ctx, confluence.PageInput{
+			SpaceKey: opts.SpaceKey,
+			Title:    d.title,
+			Body:     d.storage,
+			ParentID: parentID,
+			Labels:   d.labels,
+		})
+		if err != nil {
+			return page, mappingEntry{}, err
+		}
+		entry.ID = created.ID
+		entry.Version = created.Version.Number
+		page.ID = created.ID
+		partial = mappingEntry{ID: entry.ID, Version: entry.Version, Labels: entry.Labels}
+		if err := p.upload(ctx, entry.ID, d, attachments, nil); err != nil {
+			return page, partial, err
+		}
+		return page, entry, nil
+	}
+
+	page.ID = entry.ID
+	page.Status = StatusUpdated
+	if opts.DryRun {
+		return page, entry, nil
+	}
+
+	if err := p.upload(ctx, entry.ID, d, attachments, previous.Attachments); err != nil {
+		return page, partial, err
+	}
+	// The expected version is only known for pages this directory published
+	// before; pages found by id or title are taken over at their current
+	// version.
+	updated, err := p.service.EditPage(ctx, entry.ID, confluence.PageEdit{
+		Title:           d.title,
+		Body:            d.storage,
+		SpaceKey:        opts.SpaceKey,
+		ParentID:        parentID,
+		ExpectedVersion: previous.Version,
+	})
+	if err != nil {
+		var conflict *confluence.ConflictError
+		if errors.As(err, &conflict) {
+			err = fmt.Errorf("%w; merge the Confluence changes into %s or remove its entry from %s to overwrite them", err, d.key, MappingFile)
+		}
+		return page, partial, err
+	}
+	entry.Version = updated.Version.Number
+	partial.Version = entry.Version
+
+	if len(d.labels) > 0 {
+		if _, err := p.service.AddLabels(ctx, entry.ID, d.labels); err != nil {
+			return page, partial, err
+		}
+	}
+	for _, label := range removedLabels(previous.Labels, d.labels) {
+		if err := p.service.RemoveLabel(ctx, entry.ID, label); err != nil && !atlassian.IsStatus(err, http.StatusNotFound) {
+			return page, partial, err
+		}
+	}
+
+	return page, entry, nil
+}
+
+// removedLabels returns the labels in previous that are missing from
+// current.
+func removedLabels(previous, current []string) []string {
+	var removed []string
+	for _, label := range previous {
+		kept := false
+		for _, name := range current {
+			if strings.EqualFold(label, name) {
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			removed = append(removed, label)
+		}
+	}
+	return removed
+}
+
+// upload attaches files whose content hash differs from the last publish.
+func (p *Publisher) upload(ctx context.Context, pageID string, d *doc, hashes, previous map[string]string) error {
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if previous[name] == hashes[name] {
+			continue
+		}
+		data, err := os.ReadFile(d.attachments[name])
+		if err != nil {
+			return err
+		}
+		if _, err := p.service.UploadAttachment(ctx, pageID, confluence.AttachmentInput{
+			FileName:  name,
+			Data:      data,
+			Comment:   "Published from " + d.key,
+			MinorEdit: true,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// doc is a page to publish: a Markdown file, or a directory without one.
+type doc struct {
+	key     string // slash separated path relative to the root; directories end in "/"
+	file    string // Markdown file, empty for a bare directory
+	parent  *doc
+	title   string
+	frontID string
+	labels  []string
+	body    string
+	storage string
+	// attachments maps attachment names to local files.
+	attachments map[string]string
+}
+
+func (d *doc) hash(parentID string, attachments map[string]string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00", d.title, parentID, strings.Join(d.labels, ","), d.storage)
+	names := make([]string, 0, len(attachments))
+	for name := range attachments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\x00", name, attachments[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// scan builds the page list, parents before children, and converts every
+// body to storage format.
+func scan(root string) ([]*doc, error) {
+	var docs []*doc
+	byFile := map[string]*doc{}
+
+	var walk func(rel string, parent *doc) error
+	walk = func(rel string, parent *doc) error {
+		entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			return fmt.Errorf("publish: %w", err)
+		}
+
+		files := map[string]bool{}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(path.Ext(entry.Name()), ".md") {
+				files[entry.Name()] = true
+			}
+		}
+
+		// An index.md or README.md below the root is the directory's page.
+		if parent != nil && parent.file == "" {
+			for _, name := range []string{"index.md", "README.md", "readme.md"} {
+				if files[name] {
+					parent.file = path.Join(rel, name)
+					delete(files, name)
+					break
+				}
+			}
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				continue
+			}
+			child := path.Join(rel, name)
+
+			if entry.IsDir() {
+				d := &doc{key: child + "/", parent: parent}
+				if files[name+".md"] {
+					d.file = path.Join(rel, name+".md")
+					delete(files, name+".md")
+				}
+				docs = append(docs, d)
+				n := len(docs)
+				if err := walk(child, d); err != nil {
+					return err
+				}
+				// Directories holding only images or other assets are not pages.
+				if d.file == "" && len(docs) == n {
+					docs = docs[:n-1]
+				}
+				continue
+			}
+			if files[name] {
+				d := &doc{key: child, file: child, parent: parent}
+				docs = append(docs, d)
+			}
+		}
+		return nil
+	}
+	if err := walk("", nil); err != nil {
+		return nil, err
+	}
+
+	titles := map[string]string{}
+	for _, d := range docs {
+		if err := d.load(root); err != nil {
+			return nil, err
+		}
+		if other, ok := titles[strings.ToLower(d.title)]; ok {
+			return nil, fmt.Errorf("publish: %s and %s both have the title %q", other, d.key, d.title)
+		}
+		titles[strings.ToLower(d.title)] = d.key
+		if d.file != "" {
+			byFile[d.file] = d
+		}
+		byFile[strings.TrimSuffix(d.key, "/")] = d
+	}
+
+	for _, d := range docs {
+		d.convert(root, byFile)
+	}
+	return docs, nil
+}
+
+// load reads the front-matter, title and Markdown body of a page.
+func (d *doc) load(root string) error {
+	dir := strings.TrimSuffix(d.key, "/")
+	d.title = humanize(strings.TrimSuffix(path.Base(dir), path.Ext(dir)))
+	if d.file == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(d.file)))
+	if err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+
+	front, body := splitFrontMatter(string(data))
+	if title := front["title"]; title != "" {
+		d.title = title
+	}
+	d.frontID = front["id"]
+	d.labels = parseList(front["labels"])
+
+	// A leading H1 repeating the title, or standing in for it, is dropped.
+	trimmed := strings.TrimLeft(body, "\n")
+	if line, rest, _ := strings.Cut(trimmed, "\n"); strings.HasPrefix(line, "# ") {
+		heading := strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		if front["title"] == "" || heading == front["title"] {
+			d.title = heading
+			body = rest
+		}
+	}
+	d.body = body
+	return nil
+}
+
+// convert renders the body to storage, resolving links to other published
+// files and collecting local images and files as attachments.
+func (d *doc) convert(root string, byFile map[string]*doc) {
+	d.attachments = map[string]string{}
+	if d.file == "" {
+		d.storage = `<ac:structured-macro ac:name="children" />`
+		return
+	}
+
+	base := path.Dir(d.file)
+	resolve := func(href string) string {
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+		target := path.Clean(path.Join(base, href))
+		if target == ".." || strings.HasPrefix(target, "../") {
+			return ""
+		}
+		return target
+	}
+
+	d.storage = confluence.MarkdownToStorage(d.body, confluence.StorageLinks{
+		Page: func(href string) string {
+			if target := byFile[resolve(href)]; target != nil {
+				return target.title
+			}
+			return ""
+		},
+		Attachment: func(src string) string {
+			target := resolve(src)
+			if target == "" || strings.EqualFold(path.Ext(target), ".md") {
+				return ""
+			}
+			file := filepath.Join(root, filepath.FromSlash(target))
+			if info, err := os.Stat(file); err != nil || info.IsDir() || info.Size() == 0 {
+				return ""
+			}
+			name := path.Base(target)
+			if existing, ok := d.attachments[name]; ok && existing != file {
+				name = hashOf([]byte(target))[:8] + "-" + name
+			}
+			d.attachments[name] = file
+			return name
+		},
+	})
+	if strings.TrimSpace(d.storage) == "" {
+		d.storage = "<p></p>"
+	}
+}
+
+// splitFrontMatter separates a leading YAML front-matter block of simple
+// "key: value" lines from the body.
+func splitFrontMatter(content string) (map[string]string, string) {
+	front := map[string]string{}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return front, content
+	}
+	block, body, found := strings.Cut(content[4:], "\n---\n")
+	if !found {
+		if !strings.HasSuffix(content, "\n---") {
+			return front, content
+		}
+		block, body = strings.TrimSuffix(content[4:], "\n---"), ""
+	}
+
+	var listKey string
+	for _, line := range strings.Split(block, "\n") {
+		if item, ok := strings.CutPrefix(strings.TrimSpace(line), "- "); ok && listKey != "" {
+			front[listKey] += "," + item
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		front[key] = unquote(strings.TrimSpace(value))
+		listKey = key
+	}
+	return front, body
+}
+
+// parseList reads a flow list such as [a, "b"] or the comma joined items of
+// a block list.
+func parseList(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = unquote(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		switch {
+		case value[0] == '"' && value[len(value)-1] == '"':
+			var s string
+			if err := json.Unmarshal([]byte(value), &s); err == nil {
+				return s
+			}
+		case value[0] == '\'' && value[len(value)-1] == '\'':
+			return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		}
+	}
+	return value
+}
+
+// humanize turns a file name such as getting-started into "Getting started".
+func humanize(name string) string {
+	name = strings.Join(strings.Fields(strings.NewReplacer("-", " ", "_", " ").Replace(name)), " ")
+	if name == "" {
+		return name
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func loadMapping(dir string) (mapping, error) {
+	m := mapping{Pages: map[string]mappingEntry{}}
+	data, err := os.ReadFile(filepath.Join(dir, MappingFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return m, fmt.Errorf("publish: %w", err)
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("publish: read %s: %w", MappingFile, err)
+	}
+	if m.Pages == nil {
+		m.Pages = map[string]mappingEntry{}
+	}
+	return m, nil
+}
+
+func saveMapping(dir string, m mapping) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, MappingFile), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+	return nil
+}
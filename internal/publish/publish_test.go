@@ -0,0 +1,245 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ylchen07/atlassian-mcp/internal/atlassian"
+	"github.com/ylchen07/atlassian-mcp/internal/config"
+	"github.com/ylchen07/atlassian-mcp/internal/confluence"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPublish(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"guide.md":           "---\ntitle: User Guide\nlabels: [docs, guide]\n---\n# User Guide\n\nSee [setup](guide/setup.md).\n",
+		"guide/setup.md":     "# Setup\n\n![Diagram](img/flow.png)\n\nBack to [the guide](../guide.md).\n",
+		"guide/img/flow.png": "png",
+		"_drafts/wip.md":     "# Draft\n",
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	var calls []string
+	created := map[string]map[string]interface{}{}
+	client, err := atlassian.NewHTTPClient("https://example.com", config.ServiceCredentials{Email: "a@example.com", APIToken: "token"})
+	if err != nil {
+		t.Fatalf("NewHTTPClient error: %v", err)
+	}
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, req.Method+" "+req.URL.Path)
+		body := ""
+		switch {
+		case req.URL.Path == "/rest/api/space/DOCS":
+			body = `{"key":"DOCS","homepage":{"id":"100"}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/rest/api/content":
+			body = `{"results":[]}`
+		case req.Method == http.MethodPost && req.URL.Path == "/rest/api/content":
+			var payload map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			title := payload["title"].(string)
+			created[title] = payload
+			id := "201"
+			if title == "Setup" {
+				id = "202"
+			}
+			body = `{"id":"` + id + `","title":"` + title + `"}`
+		case req.URL.Path == "/rest/api/content/202/child/attachment":
+			if req.Method == http.MethodGet {
+				body = `{"results":[]}`
+			} else {
+				body = `{"results":[{"id":"att1","title":"flow.png"}]}`
+			}
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+
+	publisher := NewPublisher(confluence.NewService(client))
+	report, err := publisher.Publish(context.Background(), Options{Dir: dir, SpaceKey: "DOCS"})
+	if err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	if report.Created != 2 || len(report.Pages) != 2 || report.Pages[0].Path != "guide/" || report.Pages[1].Attachments != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	guide := created["User Guide"]
+	if guide == nil {
+		t.Fatalf("guide not created: %v", calls)
+	}
+	if ancestors := guide["ancestors"].([]interface{}); ancestors[0].(map[string]interface{})["id"] != "100" {
+		t.Fatalf("guide parent = %v", ancestors)
+	}
+	guideBody := guide["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	if strings.Contains(guideBody, "<h1>") || !strings.Contains(guideBody, `<ri:page ri:content-title="Setup" />`) {
+		t.Fatalf("unexpected guide body: %s", guideBody)
+	}
+
+	setup := created["Setup"]
+	if ancestors := setup["ancestors"].([]interface{}); ancestors[0].(map[string]interface{})["id"] != "201" {
+		t.Fatalf("setup parent = %v", ancestors)
+	}
+	setupBody := setup["body"].(map[string]interface{})["storage"].(map[string]interface{})["value"].(string)
+	for _, want := range []string{`<ri:attachment ri:filename="flow.png" />`, `<ri:page ri:content-title="User Guide" />`} {
+		if !strings.Contains(setupBody, want) {
+			t.Fatalf("setup body missing %q: %s", want, setupBody)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, MappingFile)); err != nil {
+		t.Fatalf("mapping not written: %v", err)
+	}
+
+	// A second run with nothing changed makes no content requests.
+	calls = nil
+	report, err = publisher.Publish(context.Background(), Options{Dir: dir, SpaceKey: "DOCS"})
+	if err != nil {
+		t.Fatalf("second Publish error: %v", err)
+	}
+	if report.Unchanged != 2 || len(calls) != 1 {
+		t.Fatalf("unexpected second run: %+v calls=%v", report, calls)
+	}
+
+	if _, err := publisher.Publish(context.Background(), Options{Dir: dir, SpaceKey: "OTHER"}); err == nil {
+		t.Fatalf("expected error publishing to a different space")
+	}
+}
+
+func TestPublishUpdatesMappedPages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md": "---\nlabels: [keep]\n---\n# A\n\nNew body.\n",
+		"b.md": "# B\n\nNew body.\n",
+		"c.md": "# C\n\nNew body.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	previous := mapping{SpaceKey: "DOCS", Pages: map[string]mappingEntry{
+		"a.md": {ID: "1", Hash: "old", Version: 3, Labels: []string{"keep", "drop"}},
+		"b.md": {ID: "2", Hash: "old", Version: 5},
+		"c.md": {ID: "3", Hash: "old", Version: 7},
+	}}
+	if err := saveMapping(dir, previous); err != nil {
+		t.Fatalf("saveMapping: %v", err)
+	}
+
+	var removed []string
+	var updated []string
+	client, err := atlassian.NewHTTPClient("https://example.com", config.ServiceCredentials{Email: "a@example.com", APIToken: "token"})
+	if err != nil {
+		t.Fatalf("NewHTTPClient error: %v", err)
+	}
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := 200, ""
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/rest/api/content/1":
+			body = `{"id":"1","type":"page","title":"A","version":{"number":3},"body":{"storage":{"value":"<p>Old</p>"}}}`
+		case req.Method == http.MethodGet && req.URL.Path == "/rest/api/content/2":
+			// Edited in Confluence since the last publish.
+			body = `{"id":"2","type":"page","title":"B","version":{"number":6},"body":{"storage":{"value":"<p>Edited</p>"}}}`
+		case req.Method == http.MethodPut:
+			updated = append(updated, req.URL.Path)
+			body = `{"id":"1","title":"A","version":{"number":4}}`
+		case req.Method == http.MethodPost && req.URL.Path == "/rest/api/content/1/label":
+			body = `{"results":[{"name":"keep"}]}`
+		case req.Method == http.MethodDelete && req.URL.Path == "/rest/api/content/1/label":
+			removed = append(removed, req.URL.Query().Get("name"))
+			status = 204
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+	})}
+
+	publisher := NewPublisher(confluence.NewService(client))
+	_, err = publisher.Publish(context.Background(), Options{Dir: dir, SpaceKey: "DOCS", ParentID: "100"})
+	var conflict *confluence.ConflictError
+	if !errors.As(err, &conflict) || conflict.ID != "2" {
+		t.Fatalf("expected conflict on page 2, got %v", err)
+	}
+	if len(updated) != 1 || updated[0] != "/rest/api/content/1" {
+		t.Fatalf("unexpected updates: %v", updated)
+	}
+	if len(removed) != 1 || removed[0] != "drop" {
+		t.Fatalf("unexpected removed labels: %v", removed)
+	}
+
+	saved, err := loadMapping(dir)
+	if err != nil {
+		t.Fatalf("loadMapping: %v", err)
+	}
+	if a := saved.Pages["a.md"]; a.Version != 4 || len(a.Labels) != 1 || a.Hash == "old" {
+		t.Fatalf("unexpected a.md entry: %+v", a)
+	}
+	if b := saved.Pages["b.md"]; b.ID != "2" || b.Version != 5 || b.Hash != "" {
+		t.Fatalf("unexpected b.md entry: %+v", b)
+	}
+	if c := saved.Pages["c.md"]; c.ID != "3" || c.Version != 7 || c.Hash != "old" {
+		t.Fatalf("unprocessed c.md entry not kept: %+v", c)
+	}
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	front, body := splitFrontMatter("---\ntitle: \"Ops: Runbook\"\nid: '42'\nlabels:\n  - ops\n  - oncall\n---\nBody\n")
+	if front["title"] != "Ops: Runbook" || front["id"] != "42" || body != "Body\n" {
+		t.Fatalf("unexpected front-matter: %v body=%q", front, body)
+	}
+	if labels := parseList(front["labels"]); len(labels) != 2 || labels[0] != "ops" || labels[1] != "oncall" {
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+
+	if _, body := splitFrontMatter("No front-matter\n"); body != "No front-matter\n" {
+		t.Fatalf("unexpected body: %q", body)
+	}
+	if got := humanize("getting-started_guide"); got != "Getting started guide" {
+		t.Fatalf("humanize = %q", got)
+	}
+	if got := humanize("éclair-notes"); got != "Éclair notes" {
+		t.Fatalf("humanize = %q", got)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "éclair-notes.md"), []byte("No heading.\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	docs, err := scan(dir)
+	if err != nil {
+		t.Fatalf("scan error: %v", err)
+	}
+	if len(docs) != 1 || docs[0].title != "Éclair notes" {
+		t.Fatalf("unexpected title for a non-ASCII file name: %+v", docs)
+	}
+}
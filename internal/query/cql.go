@@ -0,0 +1,81 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CQLFilter describes a Confluence search. Empty fields are ignored; all
+// set fields must match.
+type CQLFilter struct {
+	Spaces   []string
+	Types    []string
+	Labels   []string
+	Text     string
+	Title    string
+	Creator  string
+	Ancestor string
+
+	CreatedAfter   string
+	CreatedBefore  string
+	ModifiedAfter  string
+	ModifiedBefore string
+
+	OrderBy string
+}
+
+// BuildCQL renders a filter as CQL. Dates accept YYYY-MM-DD, "YYYY-MM-DD
+// HH:MM", relative offsets such as -7d, or CQL date functions such as
+// startOfWeek(). Creator accepts currentUser() or "me" for the caller.
+func BuildCQL(filter CQLFilter) (string, error) {
+	b := &builder{}
+	b.values("space", filter.Spaces)
+	b.values("type", filter.Types)
+
+	for _, label := range filter.Labels {
+		if label = strings.TrimSpace(label); label != "" {
+			b.add("label = %s", quote(label))
+		}
+	}
+
+	if text := strings.TrimSpace(filter.Text); text != "" {
+		b.add("text ~ %s", quote(escapeText(text)))
+	}
+	if title := strings.TrimSpace(filter.Title); title != "" {
+		b.add("title ~ %s", quote(title))
+	}
+
+	if creator := strings.TrimSpace(filter.Creator); creator != "" {
+		if isCurrentUser(creator) {
+			b.add("creator = currentUser()")
+		} else {
+			b.add("creator = %s", quote(creator))
+		}
+	}
+
+	if ancestor := strings.TrimSpace(filter.Ancestor); ancestor != "" {
+		b.add("ancestor = %s", quote(ancestor))
+	}
+
+	b.dateRange("created", filter.CreatedAfter, filter.CreatedBefore, cqlDate)
+	b.dateRange("lastmodified", filter.ModifiedAfter, filter.ModifiedBefore, cqlDate)
+
+	return b.build(filter.OrderBy)
+}
+
+// cqlDate renders a CQL date value. Relative offsets become now("-7d").
+func cqlDate(value string) (string, error) {
+	switch {
+	case absoluteDate.MatchString(value):
+		return quote(value), nil
+	case relativeDate.MatchString(value):
+		if !strings.HasPrefix(value, "-") && !strings.HasPrefix(value, "+") {
+			value = "+" + value
+		}
+		return fmt.Sprintf("now(%s)", quote(value)), nil
+	case dateFunction.MatchString(value):
+		return value, nil
+	}
+
+	return "", fmt.Errorf("query: unsupported date %q; use YYYY-MM-DD, an offset such as -7d or a function such as startOfWeek()", value)
+}
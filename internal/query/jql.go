@@ -0,0 +1,112 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JQLFilter describes a Jira issue search. Empty fields are ignored; all
+// set fields must match.
+type JQLFilter struct {
+	Projects    []string
+	Types       []string
+	Statuses    []string
+	Priorities  []string
+	Labels      []string
+	FixVersions []string
+	Assignee    string
+	Reporter    string
+	Text        string
+	Sprint      string
+	Unresolved  bool
+
+	CreatedAfter  string
+	CreatedBefore string
+	UpdatedAfter  string
+	UpdatedBefore string
+
+	OrderBy string
+}
+
+// BuildJQL renders a filter as JQL. Assignee and Reporter accept
+// currentUser() or "me" for the caller and "unassigned" for an empty field.
+// Sprint accepts "open" or "closed" for openSprints() and closedSprints(),
+// a numeric sprint ID or a sprint name. Dates accept YYYY-MM-DD, "YYYY-MM-DD
+// HH:MM", relative offsets such as -7d, or JQL date functions.
+func BuildJQL(filter JQLFilter) (string, error) {
+	b := &builder{}
+	b.values("project", filter.Projects)
+	b.values("issuetype", filter.Types)
+	b.values("status", filter.Statuses)
+	b.values("priority", filter.Priorities)
+	b.values("fixVersion", filter.FixVersions)
+
+	for _, label := range filter.Labels {
+		if label = strings.TrimSpace(label); label != "" {
+			b.add("labels = %s", quote(label))
+		}
+	}
+
+	jqlUser(b, "assignee", filter.Assignee)
+	jqlUser(b, "reporter", filter.Reporter)
+
+	if text := strings.TrimSpace(filter.Text); text != "" {
+		b.add("text ~ %s", quote(escapeText(text)))
+	}
+
+	if sprint := strings.TrimSpace(filter.Sprint); sprint != "" {
+		switch {
+		case strings.EqualFold(sprint, "open"), strings.EqualFold(sprint, "current"):
+			b.add("sprint in openSprints()")
+		case strings.EqualFold(sprint, "closed"):
+			b.add("sprint in closedSprints()")
+		case isDigits(sprint):
+			b.add("sprint = %s", sprint)
+		default:
+			b.add("sprint = %s", quote(sprint))
+		}
+	}
+
+	if filter.Unresolved {
+		b.add("resolution is EMPTY")
+	}
+
+	b.dateRange("created", filter.CreatedAfter, filter.CreatedBefore, jqlDate)
+	b.dateRange("updated", filter.UpdatedAfter, filter.UpdatedBefore, jqlDate)
+
+	return b.build(filter.OrderBy)
+}
+
+func jqlUser(b *builder, field, value string) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+	case isCurrentUser(value):
+		b.add("%s = currentUser()", field)
+	case strings.EqualFold(value, "unassigned"), strings.EqualFold(value, "empty"):
+		b.add("%s is EMPTY", field)
+	default:
+		b.add("%s = %s", field, quote(value))
+	}
+}
+
+// jqlDate renders a JQL date value. Relative offsets are left bare.
+func jqlDate(value string) (string, error) {
+	switch {
+	case absoluteDate.MatchString(value):
+		return quote(value), nil
+	case relativeDate.MatchString(value), dateFunction.MatchString(value):
+		return value, nil
+	}
+
+	return "", fmt.Errorf("query: unsupported date %q; use YYYY-MM-DD, an offset such as -7d or a function such as startOfWeek()", value)
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
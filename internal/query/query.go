@@ -0,0 +1,146 @@
+// Package query composes CQL and JQL queries from structured filters so
+// callers never have to hand-quote values.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	absoluteDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}( \d{2}:\d{2})?$`)
+	relativeDate = regexp.MustCompile(`^[+-]?\d+[wdhm]$`)
+	dateFunction = regexp.MustCompile(`^(now|startOfDay|startOfWeek|startOfMonth|startOfYear|endOfDay|endOfWeek|endOfMonth|endOfYear)\(\s*("?[+-]?\d+[wdhmMy]?"?)?\s*\)$`)
+	orderField   = regexp.MustCompile(`^[A-Za-z][\w.]*(\[\d+\])?$`)
+)
+
+// quote renders a string literal, escaping quotes and backslashes. CQL and
+// JQL share the same literal syntax.
+func quote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// luceneSpecials are escaped in text searches so they are matched literally.
+var luceneSpecials = strings.NewReplacer(
+	`\`, `\\`, `+`, `\+`, `-`, `\-`, `&`, `\&`, `|`, `\|`, `!`, `\!`,
+	`(`, `\(`, `)`, `\)`, `{`, `\{`, `}`, `\}`, `[`, `\[`, `]`, `\]`,
+	`^`, `\^`, `~`, `\~`, `*`, `\*`, `?`, `\?`, `:`, `\:`, `/`, `\/`,
+)
+
+// luceneOperators are the boolean operators of the Lucene syntax behind
+// both text ~ searches; Lucene only honours them in upper case.
+var luceneOperators = regexp.MustCompile(`\b(AND|OR|NOT)\b`)
+
+// escapeText prepares a value for a text ~ clause so it is searched as
+// words rather than parsed as Lucene query syntax.
+func escapeText(text string) string {
+	return luceneOperators.ReplaceAllStringFunc(luceneSpecials.Replace(text), strings.ToLower)
+}
+
+// builder accumulates AND-ed clauses.
+type builder struct {
+	clauses []string
+	err     error
+}
+
+func (b *builder) add(format string, args ...any) {
+	b.clauses = append(b.clauses, fmt.Sprintf(format, args...))
+}
+
+// values adds `field = "v"` for one value or `field in ("a", "b")` for
+// several. Blank values are ignored.
+func (b *builder) values(field string, values []string) {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			quoted = append(quoted, quote(value))
+		}
+	}
+
+	switch len(quoted) {
+	case 0:
+	case 1:
+		b.add("%s = %s", field, quoted[0])
+	default:
+		b.add("%s in (%s)", field, strings.Join(quoted, ", "))
+	}
+}
+
+// dateRange adds lower and upper bounds on a date field. Bounds are rendered
+// with render, which reports unsupported date syntax.
+func (b *builder) dateRange(field, after, before string, render func(string) (string, error)) {
+	for _, bound := range []struct{ op, value string }{{">=", after}, {"<", before}} {
+		value := strings.TrimSpace(bound.value)
+		if value == "" {
+			continue
+		}
+		rendered, err := render(value)
+		if err != nil {
+			b.fail(fmt.Errorf("%s: %w", field, err))
+			continue
+		}
+		b.add("%s %s %s", field, bound.op, rendered)
+	}
+}
+
+func (b *builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// build joins the clauses and appends the ORDER BY clause.
+func (b *builder) build(orderBy string) (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	query := strings.Join(b.clauses, " AND ")
+	order, err := renderOrderBy(orderBy)
+	if err != nil {
+		return "", err
+	}
+	if order != "" {
+		if query == "" {
+			return "", fmt.Errorf("query: at least one filter is required with orderBy")
+		}
+		query += " ORDER BY " + order
+	}
+	if query == "" {
+		return "", fmt.Errorf("query: at least one filter is required")
+	}
+
+	return query, nil
+}
+
+// renderOrderBy validates a comma separated list of "field [ASC|DESC]".
+func renderOrderBy(orderBy string) (string, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return "", nil
+	}
+
+	var terms []string
+	for _, term := range strings.Split(orderBy, ",") {
+		parts := strings.Fields(term)
+		if len(parts) == 0 || len(parts) > 2 || !orderField.MatchString(parts[0]) {
+			return "", fmt.Errorf("query: invalid orderBy term %q", strings.TrimSpace(term))
+		}
+		if len(parts) == 2 {
+			direction := strings.ToUpper(parts[1])
+			if direction != "ASC" && direction != "DESC" {
+				return "", fmt.Errorf("query: invalid sort direction %q", parts[1])
+			}
+			parts[1] = direction
+		}
+		terms = append(terms, strings.Join(parts, " "))
+	}
+
+	return strings.Join(terms, ", "), nil
+}
+
+// isCurrentUser reports whether a user filter refers to the caller.
+func isCurrentUser(value string) bool {
+	return strings.EqualFold(value, "currentUser()") || strings.EqualFold(value, "me")
+}
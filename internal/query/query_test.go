@@ -0,0 +1,97 @@
+package query
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildCQL(t *testing.T) {
+	t.Parallel()
+
+	cql, err := BuildCQL(CQLFilter{
+		Spaces:        []string{"OPS", "DOC"},
+		Types:         []string{"page"},
+		Labels:        []string{"runbook"},
+		Text:          `say "and" \ or`,
+		Creator:       "me",
+		ModifiedAfter: "-7d",
+		CreatedBefore: "2026-01-31",
+		OrderBy:       "lastmodified desc",
+	})
+	if err != nil {
+		t.Fatalf("BuildCQL error: %v", err)
+	}
+
+	want := `space in ("OPS", "DOC") AND type = "page" AND label = "runbook" AND text ~ "say \"and\" \\\\ or" AND creator = currentUser() AND created < "2026-01-31" AND lastmodified >= now("-7d") ORDER BY lastmodified DESC`
+	if cql != want {
+		t.Fatalf("unexpected CQL:\n got %s\nwant %s", cql, want)
+	}
+}
+
+func TestBuildCQLEscapesTextSyntax(t *testing.T) {
+	t.Parallel()
+
+	cql, err := BuildCQL(CQLFilter{Text: "foo AND bar* OR (baz)"})
+	if err != nil {
+		t.Fatalf("BuildCQL error: %v", err)
+	}
+
+	if want := `text ~ "foo and bar\\* or \\(baz\\)"`; cql != want {
+		t.Fatalf("unexpected CQL:\n got %s\nwant %s", cql, want)
+	}
+}
+
+func TestBuildJQL(t *testing.T) {
+	t.Parallel()
+
+	jql, err := BuildJQL(JQLFilter{
+		Projects:     []string{"OPS"},
+		Statuses:     []string{"In Progress", "To Do"},
+		Assignee:     "unassigned",
+		Reporter:     "jane.doe",
+		Text:         "crash [prod]",
+		Sprint:       "open",
+		Unresolved:   true,
+		UpdatedAfter: "startOfWeek(-1)",
+	})
+	if err != nil {
+		t.Fatalf("BuildJQL error: %v", err)
+	}
+
+	want := `project = "OPS" AND status in ("In Progress", "To Do") AND assignee is EMPTY AND reporter = "jane.doe" AND text ~ "crash \\[prod\\]" AND sprint in openSprints() AND resolution is EMPTY AND updated >= startOfWeek(-1)`
+	if jql != want {
+		t.Fatalf("unexpected JQL:\n got %s\nwant %s", jql, want)
+	}
+
+	if jql, _ := BuildJQL(JQLFilter{Sprint: "42"}); jql != "sprint = 42" {
+		t.Fatalf("unexpected sprint clause: %s", jql)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"empty", func() error { _, err := BuildCQL(CQLFilter{}); return err }, "at least one filter"},
+		{"bad date", func() error {
+			_, err := BuildJQL(JQLFilter{Projects: []string{"A"}, CreatedAfter: "last week"})
+			return err
+		}, "unsupported date"},
+		{"bad order", func() error {
+			_, err := BuildJQL(JQLFilter{Projects: []string{"A"}, OrderBy: "created; drop"})
+			return err
+		}, "invalid orderBy"},
+		{"bad direction", func() error { _, err := BuildCQL(CQLFilter{Spaces: []string{"A"}, OrderBy: "title UP"}); return err }, "invalid sort direction"},
+	}
+
+	for _, tc := range cases {
+		err := tc.run()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}